@@ -0,0 +1,37 @@
+package mpv
+
+import (
+	"fmt"
+	"strings"
+)
+
+// parseAddress splits a ClientOptions.SocketPath value into a scheme and the
+// remainder of the address, e.g. "tcp://127.0.0.1:9001" -> ("tcp",
+// "127.0.0.1:9001"). A bare path with no "scheme://" prefix, such as
+// "/tmp/mpvsocket" or the Windows pipe path \\.\pipe\mpvsocket, returns an
+// empty scheme so callers fall back to the platform's native transport.
+func parseAddress(address string) (scheme, target string) {
+	if i := strings.Index(address, "://"); i >= 0 {
+		return address[:i], address[i+3:]
+	}
+	return "", address
+}
+
+// inputIPCServerArg builds the --input-ipc-server flag value mpv expects:
+// a bare unix socket path or Windows named pipe path, with any of our
+// "unix://"/"npipe://" scheme prefixes stripped. "tcp://" addresses are
+// rejected since mpv has no built-in way to serve its JSON IPC over TCP;
+// callers that want ClientOptions.SocketPath's tcp:// support to control a
+// remote mpv need to start that mpv themselves and dial in, rather than
+// going through Process.
+func inputIPCServerArg(address string) (string, error) {
+	scheme, target := parseAddress(address)
+	switch scheme {
+	case "tcp":
+		return "", fmt.Errorf("mpv: --input-ipc-server can't serve over tcp; start mpv separately and dial %q instead", address)
+	case "":
+		return address, nil
+	default:
+		return target, nil
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
 type (
@@ -39,16 +40,46 @@ type Request struct {
 	Cancel   context.CancelFunc
 }
 
-type eventHandler struct {
-	sync bool
-	fn   func(map[string]any)
-}
-
 type Client struct {
 	ipc             *ipc
 	eventHandlersMu sync.Mutex
 	eventHandlers   []*eventHandler
 	observerID      atomic.Int64
+	droppedEvents   atomic.Int64
+
+	// subsMu/subs and stickyMu/sticky back reconnect support: when the
+	// transport re-dials, reapplyAfterReconnect replays both so observers
+	// and previously-set properties survive an mpv restart.
+	subsMu sync.Mutex
+	subs   map[int64]string
+
+	stickyMu sync.Mutex
+	sticky   map[string]any
+
+	// logLevelMu/logLevel remember the level passed to
+	// SetLogMessageLevel so it can be re-requested after a reconnect.
+	logLevelMu sync.Mutex
+	logLevel   string
+
+	// reconnectHooksMu/reconnectHooks back OnReconnect: callers can
+	// restore state Client itself doesn't track (e.g. hook registrations,
+	// client-message listeners) once reapplyAfterReconnect is done.
+	reconnectHooksMu sync.Mutex
+	reconnectHooks   []func()
+
+	// interceptorsMu/interceptors back Use: every Command and Batch
+	// invocation runs through this chain before reaching the wire.
+	interceptorsMu sync.Mutex
+	interceptors   []CommandInterceptor
+
+	// asyncInterceptorsMu/asyncInterceptors back UseAsync: every
+	// CommandAsync and CommandAsyncMany invocation runs through this chain
+	// before reaching the wire.
+	asyncInterceptorsMu sync.Mutex
+	asyncInterceptors   []AsyncCommandInterceptor
+
+	// Properties exposes typed accessors for well-known mpv properties.
+	Properties Properties
 }
 
 func (c *Client) Close() error {
@@ -215,8 +246,18 @@ func (c *Client) ObserveProperty(ctx context.Context, property string, fn func(a
 		return nil, fmt.Errorf("failed to observe property: %w", err)
 	}
 
+	c.subsMu.Lock()
+	if c.subs == nil {
+		c.subs = make(map[int64]string)
+	}
+	c.subs[observerID] = property
+	c.subsMu.Unlock()
+
 	return func() error {
 		rmEventHandler()
+		c.subsMu.Lock()
+		delete(c.subs, observerID)
+		c.subsMu.Unlock()
 		if _, err := c.Command(ctx, "unobserve_property", observerID); err != nil {
 			return fmt.Errorf("failed to unobserve property: %w", err)
 		}
@@ -224,27 +265,135 @@ func (c *Client) ObserveProperty(ctx context.Context, property string, fn func(a
 	}, nil
 }
 
+// SetPropertySticky behaves like SetProperty, but also remembers the value so
+// that it is re-applied automatically if the client reconnects (see
+// ClientOptions.Reconnect), e.g. after mpv itself was restarted.
+func (c *Client) SetPropertySticky(ctx context.Context, property string, value any) error {
+	if err := c.SetProperty(ctx, property, value); err != nil {
+		return err
+	}
+	c.stickyMu.Lock()
+	if c.sticky == nil {
+		c.sticky = make(map[string]any)
+	}
+	c.sticky[property] = value
+	c.stickyMu.Unlock()
+	return nil
+}
+
+// SetLogMessageLevel requests mpv log messages at level (see
+// https://mpv.io/manual/stable/#command-interface-request-log-messages)
+// and remembers it so it's automatically re-requested after a reconnect.
+func (c *Client) SetLogMessageLevel(ctx context.Context, level string) error {
+	if _, err := c.Command(ctx, "request_log_messages", level); err != nil {
+		return err
+	}
+	c.logLevelMu.Lock()
+	c.logLevel = level
+	c.logLevelMu.Unlock()
+	return nil
+}
+
+// OnReconnect registers a hook that runs every time the IPC transport
+// reconnects, after observe_property subscriptions and sticky properties
+// have already been reapplied. Use it to restore state Client itself
+// doesn't track, such as hook registrations or client-message listeners.
+// The returned function removes the hook.
+func (c *Client) OnReconnect(fn func()) (rm func()) {
+	c.reconnectHooksMu.Lock()
+	defer c.reconnectHooksMu.Unlock()
+	c.reconnectHooks = append(c.reconnectHooks, fn)
+	idx := len(c.reconnectHooks) - 1
+	return func() {
+		c.reconnectHooksMu.Lock()
+		defer c.reconnectHooksMu.Unlock()
+		if idx < len(c.reconnectHooks) {
+			c.reconnectHooks[idx] = nil
+		}
+	}
+}
+
+// reapplyAfterReconnect is called once the IPC transport has re-dialed. It
+// re-issues observe_property for every subscription registered through
+// ObserveProperty, re-applies every property set through SetPropertySticky,
+// re-requests the log message level set through SetLogMessageLevel, and
+// finally runs any hooks registered through OnReconnect, since a freshly
+// (re)started mpv process won't remember any of it.
+func (c *Client) reapplyAfterReconnect() {
+	ctx := context.Background()
+
+	c.subsMu.Lock()
+	subs := make(map[int64]string, len(c.subs))
+	for id, prop := range c.subs {
+		subs[id] = prop
+	}
+	c.subsMu.Unlock()
+	for id, prop := range subs {
+		c.Command(ctx, "observe_property", id, prop)
+	}
+
+	c.stickyMu.Lock()
+	sticky := make(map[string]any, len(c.sticky))
+	for prop, value := range c.sticky {
+		sticky[prop] = value
+	}
+	c.stickyMu.Unlock()
+	for prop, value := range sticky {
+		c.SetProperty(ctx, prop, value)
+	}
+
+	c.logLevelMu.Lock()
+	level := c.logLevel
+	c.logLevelMu.Unlock()
+	if level != "" {
+		c.Command(ctx, "request_log_messages", level)
+	}
+
+	c.reconnectHooksMu.Lock()
+	hooks := append([]func(){}, c.reconnectHooks...)
+	c.reconnectHooksMu.Unlock()
+	for _, hook := range hooks {
+		if hook != nil {
+			hook()
+		}
+	}
+}
+
+// runKeepalive issues a cheap no-op command every interval and forces a
+// reconnect if it goes unanswered for timeout, so a half-open connection is
+// noticed even though the socket itself never errors.
+func (c *Client) runKeepalive(interval, timeout time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.ipc.closeCh:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			_, err := c.Command(ctx, "get_property_string", "client-name")
+			cancel()
+			if err != nil {
+				c.ipc.forceReconnect()
+			}
+		}
+	}
+}
+
 // Command sends a command to MPV. See https://mpv.io/manual/stable/#list-of-input-commands
-// for a list of commands and their arguments.
+// for a list of commands and their arguments. It runs through any
+// interceptors registered with Use.
 func (c *Client) Command(ctx context.Context, command string, args ...any) (any, error) {
-	return c.command(ctx, false, command, args...)
+	return c.invoke(ctx, command, args)
 }
 
 // CommandAsync sends a command to MPV as an asynchronous command.
 // Returns an AsyncRequest that can be used to wait for the response or
-// cancel the request.
-func (c *Client) CommandAsync(ctx context.Context, command string, args ...any) (preq Request, err error) {
-	ctx, cancel := context.WithCancel(ctx)
-	req, err := c.ipc.startRequest(ctx, true, append([]any{command}, args...)...)
-	if err != nil {
-		cancel()
-		return
-	}
-	preq.ID = req.command.RequestID
-	preq.Response = req.resp
-	preq.Error = req.err
-	preq.Cancel = cancel
-	return
+// cancel the request. It runs through any interceptors registered with
+// UseAsync.
+func (c *Client) CommandAsync(ctx context.Context, command string, args ...any) (Request, error) {
+	return c.invokeAsync(ctx, command, args)
 }
 
 // AddEventHandlerSync adds a synchronous event handler to the client.
@@ -252,21 +401,32 @@ func (c *Client) CommandAsync(ctx context.Context, command string, args ...any)
 func (c *Client) AddEventHandlerSync(fn func(map[string]any)) (rm func()) {
 	c.eventHandlersMu.Lock()
 	defer c.eventHandlersMu.Unlock()
-	handler := &eventHandler{sync: true, fn: fn}
+	handler := newEventHandler(true, fn)
 	c.eventHandlers = append(c.eventHandlers, handler)
 	return c.removeEventHandler(handler)
 }
 
-// AddEventHandler adds an event handler to the client. This handler will be
-// called in a new goroutine when an event is received.
-func (c *Client) AddEventHandler(fn func(map[string]any)) (rm func()) {
+// AddEventHandler adds an event handler to the client. Unlike
+// AddEventHandlerSync, this handler gets its own bounded queue and dedicated
+// goroutine, so a slow handler cannot stall delivery to other handlers. See
+// EventHandlerOption for configuring its capacity and overflow policy.
+func (c *Client) AddEventHandler(fn func(map[string]any), opts ...EventHandlerOption) (rm func()) {
 	c.eventHandlersMu.Lock()
 	defer c.eventHandlersMu.Unlock()
-	handler := &eventHandler{sync: false, fn: fn}
+	handler := newEventHandler(false, fn, opts...)
+	handler.reportDrop = func() { c.droppedEvents.Add(1) }
 	c.eventHandlers = append(c.eventHandlers, handler)
 	return c.removeEventHandler(handler)
 }
 
+// DroppedEvents returns the number of events lost across every
+// AddEventHandler queue on this client because of an overflow policy other
+// than OverflowBlock. The count is cumulative and survives the handlers that
+// produced it being removed.
+func (c *Client) DroppedEvents() int64 {
+	return c.droppedEvents.Load()
+}
+
 func (c *Client) removeEventHandler(handler *eventHandler) func() {
 	return func() {
 		c.eventHandlersMu.Lock()
@@ -274,6 +434,7 @@ func (c *Client) removeEventHandler(handler *eventHandler) func() {
 		for i, h := range c.eventHandlers {
 			if h == handler {
 				c.eventHandlers = append(c.eventHandlers[:i], c.eventHandlers[i+1:]...)
+				h.close()
 				return
 			}
 		}
@@ -283,25 +444,33 @@ func (c *Client) removeEventHandler(handler *eventHandler) func() {
 func (c *Client) acceptEvents() {
 	for event := range c.ipc.events {
 		c.eventHandlersMu.Lock()
-		for _, handler := range c.eventHandlers {
-			if handler.sync {
-				handler.fn(event)
-			} else {
-				go handler.fn(event)
+		handlers := append([]*eventHandler(nil), c.eventHandlers...)
+		c.eventHandlersMu.Unlock()
+
+		for _, handler := range handlers {
+			if handler.dispatch(event) {
+				c.removeEventHandler(handler)()
 			}
 		}
-		c.eventHandlersMu.Unlock()
 	}
 }
 
 func (c *Client) command(ctx context.Context, async bool, command string, args ...any) (data any, err error) {
 	args = append([]any{command}, args...)
-	resp, err := c.ipc.sendCommandSync(ctx, async, args...)
+	req, err := c.ipc.startRequest(ctx, async, args...)
 	if err != nil {
 		return nil, err
 	}
-	if !resp.Success() {
-		return nil, fmt.Errorf("mpv: command failed: %s", resp.Error)
+
+	select {
+	case resp := <-req.resp:
+		if !resp.Success() {
+			return nil, fmt.Errorf("mpv: command failed: %s", resp.Error)
+		}
+		return resp.Data, nil
+	case err := <-req.err:
+		return nil, err
+	case <-ctx.Done():
+		return nil, ctx.Err()
 	}
-	return resp.Data, nil
 }
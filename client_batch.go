@@ -0,0 +1,133 @@
+package mpv
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ClientBatch accumulates commands to send to mpv, then flushes them as a
+// single `command_list` request instead of one IPC round-trip per command.
+// Build one with Client.Batch, queue commands with its chainable methods,
+// then call Do to send the list and wait for every result. Unlike
+// MPVClient's Batch, which pipelines one request per command, the whole
+// list is sent as one "command_list" invocation and runs through the
+// Client's interceptor chain (see Use) as a single unit.
+type ClientBatch struct {
+	client   *Client
+	commands [][]any
+}
+
+// Batch returns a new, empty ClientBatch bound to c.
+func (c *Client) Batch() *ClientBatch {
+	return &ClientBatch{client: c}
+}
+
+// Command queues an arbitrary mpv command. See
+// https://mpv.io/manual/stable/#list-of-input-commands.
+func (b *ClientBatch) Command(command string, args ...any) *ClientBatch {
+	b.commands = append(b.commands, append([]any{command}, args...))
+	return b
+}
+
+func (b *ClientBatch) SetProperty(property string, value any) *ClientBatch {
+	return b.Command("set_property", property, value)
+}
+
+func (b *ClientBatch) Seek(position float64, flags ...SeekFlag) *ClientBatch {
+	if len(flags) == 0 {
+		return b.Command("seek", position)
+	}
+	flag := strings.Builder{}
+	for i, f := range flags {
+		if i > 0 {
+			flag.WriteRune('+')
+		}
+		flag.WriteString(string(f))
+	}
+	return b.Command("seek", position, flag.String())
+}
+
+func (b *ClientBatch) LoadFile(file string, mode LoadFileMode) *ClientBatch {
+	return b.Command("loadfile", file, string(mode))
+}
+
+// BatchResult is one queued command's outcome, at the same index it was
+// queued at.
+type BatchResult struct {
+	Data any
+	Err  error
+}
+
+// Do flushes every queued command as a single mpv `command_list` request —
+// one IPC round-trip instead of one per command — and waits for the
+// combined response. The call runs through the interceptor chain
+// registered with Use, exactly like Command. Results are returned in the
+// order the commands were queued. err is non-nil only if the command_list
+// request as a whole failed (it could not be sent or answered, mpv rejected
+// it, or an interceptor returned an error) or mpv's response didn't hold one
+// result per queued command; a failure of one queued command surfaces as
+// that command's BatchResult.Err instead.
+func (b *ClientBatch) Do(ctx context.Context) ([]BatchResult, error) {
+	n := len(b.commands)
+	if n == 0 {
+		return nil, nil
+	}
+
+	rawCommands := make([]any, n)
+	for i, cmd := range b.commands {
+		rawCommands[i] = cmd
+	}
+
+	data, err := b.client.invoke(ctx, "command_list", []any{rawCommands})
+	if err != nil {
+		return nil, err
+	}
+
+	items, ok := data.([]any)
+	if !ok || len(items) != n {
+		return nil, fmt.Errorf("mpv: command_list returned %d results, want %d", len(items), n)
+	}
+
+	results := make([]BatchResult, n)
+	for i, item := range items {
+		entry, ok := item.(map[string]any)
+		if !ok {
+			results[i] = BatchResult{Err: fmt.Errorf("mpv: command_list result %d has unexpected shape: %T", i, item)}
+			continue
+		}
+		if errStr, _ := entry["error"].(string); errStr != "" && errStr != "success" {
+			results[i] = BatchResult{Err: fmt.Errorf("mpv: command failed: %s", errStr)}
+			continue
+		}
+		results[i] = BatchResult{Data: entry["data"]}
+	}
+	return results, nil
+}
+
+// CommandAsyncMany fires every given command concurrently without waiting
+// for a response before starting the next, returning a Request per command
+// in the same order. Each Request can be waited on or canceled
+// independently, exactly like one returned by CommandAsync. If any entry is
+// empty, has a non-string first element, or fails to start,
+// CommandAsyncMany returns immediately with an error and the requests
+// started so far, so indices into the returned slice always line up with
+// the commands that preceded the failure.
+func (c *Client) CommandAsyncMany(ctx context.Context, commands ...[]any) ([]Request, error) {
+	reqs := make([]Request, 0, len(commands))
+	for i, args := range commands {
+		if len(args) == 0 {
+			return reqs, fmt.Errorf("mpv: command %d is empty", i)
+		}
+		name, ok := args[0].(string)
+		if !ok {
+			return reqs, fmt.Errorf("mpv: command %d: first element must be a string, got %T", i, args[0])
+		}
+		req, err := c.CommandAsync(ctx, name, args[1:]...)
+		if err != nil {
+			return reqs, err
+		}
+		reqs = append(reqs, req)
+	}
+	return reqs, nil
+}
@@ -0,0 +1,104 @@
+package mpv
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+)
+
+// DialOption configures Dial.
+type DialOption func(*dialOptions)
+
+type dialOptions struct {
+	timeout     time.Duration
+	reconnect   ReconnectPolicy
+	onReconnect func()
+}
+
+// WithDialerTimeout sets how long the initial connection attempt, and every
+// subsequent redial, may take. Defaults to 5 seconds.
+func WithDialerTimeout(d time.Duration) DialOption {
+	return func(o *dialOptions) { o.timeout = d }
+}
+
+// WithReconnectPolicy tunes the backoff and keepalive behavior Dial uses to
+// keep the connection alive. Omit it to use ReconnectPolicy's defaults.
+func WithReconnectPolicy(policy ReconnectPolicy) DialOption {
+	return func(o *dialOptions) { o.reconnect = policy }
+}
+
+// WithOnReconnect registers a hook that runs after every successful
+// reconnect, once subscriptions, sticky properties, and the log message
+// level have been reapplied. Equivalent to calling Client.OnReconnect
+// immediately after Dial returns.
+func WithOnReconnect(fn func()) DialOption {
+	return func(o *dialOptions) { o.onReconnect = fn }
+}
+
+// Dial connects to mpv's JSON IPC socket at socketPath (see
+// ClientOptions.SocketPath for the accepted address forms) and returns a
+// Client with automatic reconnection always enabled: a broken connection is
+// redialed with exponential backoff, observe_property subscriptions and
+// sticky properties are reapplied, and in-flight requests are replayed or
+// failed with ErrDisconnected. Unlike OpenClientWithOptions, which only
+// reconnects if ClientOptions.Reconnect is set, Dial always does; use
+// OpenClientWithOptions instead if reconnection should stay opt-in.
+//
+// ctx bounds only the initial connection attempt, not the client's
+// lifetime.
+func Dial(ctx context.Context, socketPath string, opts ...DialOption) (*Client, error) {
+	cfg := dialOptions{timeout: 5 * time.Second}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	type dialResult struct {
+		ipc *ipc
+		err error
+	}
+	resultCh := make(chan dialResult, 1)
+	go func() {
+		conn, err := openIPC(socketPath, cfg.timeout)
+		resultCh <- dialResult{conn, err}
+	}()
+
+	var result dialResult
+	select {
+	case <-ctx.Done():
+		// openIPC is still running in the background and may yet succeed,
+		// spawning writeLoop/readLoop over a live connection nobody would
+		// otherwise hold a reference to. Close it the moment it lands
+		// instead of leaking the goroutines and the socket.
+		go func() {
+			if r := <-resultCh; r.ipc != nil {
+				r.ipc.close()
+			}
+		}()
+		return nil, ctx.Err()
+	case result = <-resultCh:
+	}
+	if result.err != nil {
+		return nil, fmt.Errorf("failed to open IPC: %w", result.err)
+	}
+
+	client := &Client{ipc: result.ipc}
+	client.Properties = Properties{c: client}
+
+	policy := cfg.reconnect
+	policy.applyDefaults()
+	dial := func() (net.Conn, error) {
+		return dialTransport(socketPath, cfg.timeout)
+	}
+	result.ipc.enableReconnect(policy, dial, client.reapplyAfterReconnect)
+
+	if policy.KeepaliveInterval > 0 {
+		go client.runKeepalive(policy.KeepaliveInterval, policy.KeepaliveTimeout)
+	}
+	if cfg.onReconnect != nil {
+		client.OnReconnect(cfg.onReconnect)
+	}
+
+	go client.acceptEvents()
+	return client, nil
+}
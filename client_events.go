@@ -0,0 +1,64 @@
+package mpv
+
+import "context"
+
+// Events returns a channel of typed events alongside a function to stop
+// delivering to it. See MPVClient.Events for the same API on the legacy
+// client; both share the same decoder and event pump.
+func (c *Client) Events(opts ...EventHandlerOption) (events <-chan Event, rm func()) {
+	ch := make(chan Event, defaultEventQueueCapacity)
+	rm = c.AddEventHandler(func(raw map[string]any) {
+		ch <- decodeEvent(raw)
+	}, opts...)
+	return ch, rm
+}
+
+// The On* methods below are typed, single-event-type convenience wrappers
+// around AddTypedEventHandler for the mpv events most commonly handled one at
+// a time. For anything else, use AddTypedEventHandler or Events directly.
+
+func (c *Client) OnStartFile(fn func(EventStartFile), opts ...EventHandlerOption) (rm func()) {
+	return AddTypedEventHandler(c, fn, opts...)
+}
+
+func (c *Client) OnEndFile(fn func(EventEndFile), opts ...EventHandlerOption) (rm func()) {
+	return AddTypedEventHandler(c, fn, opts...)
+}
+
+func (c *Client) OnFileLoaded(fn func(EventFileLoaded), opts ...EventHandlerOption) (rm func()) {
+	return AddTypedEventHandler(c, fn, opts...)
+}
+
+func (c *Client) OnSeek(fn func(EventSeek), opts ...EventHandlerOption) (rm func()) {
+	return AddTypedEventHandler(c, fn, opts...)
+}
+
+func (c *Client) OnPlaybackRestart(fn func(EventPlaybackRestart), opts ...EventHandlerOption) (rm func()) {
+	return AddTypedEventHandler(c, fn, opts...)
+}
+
+func (c *Client) OnShutdown(fn func(EventShutdown), opts ...EventHandlerOption) (rm func()) {
+	return AddTypedEventHandler(c, fn, opts...)
+}
+
+func (c *Client) OnLogMessage(fn func(EventLogMessage), opts ...EventHandlerOption) (rm func()) {
+	return AddTypedEventHandler(c, fn, opts...)
+}
+
+func (c *Client) OnClientMessage(fn func(EventClientMessage), opts ...EventHandlerOption) (rm func()) {
+	return AddTypedEventHandler(c, fn, opts...)
+}
+
+func (c *Client) OnHook(fn func(EventHook), opts ...EventHandlerOption) (rm func()) {
+	return AddTypedEventHandler(c, fn, opts...)
+}
+
+// OnPropertyChange observes property and calls fn with the decoded
+// property-change event every time it fires. It is a typed counterpart to
+// ObserveProperty; for automatic conversion of Data into a concrete type, use
+// the generic ObserveProperty function instead.
+func (c *Client) OnPropertyChange(ctx context.Context, property string, fn func(EventPropertyChange)) (rm func() error, err error) {
+	return c.ObserveProperty(ctx, property, func(data any) {
+		fn(EventPropertyChange{Name: property, Data: data})
+	})
+}
@@ -0,0 +1,38 @@
+package mpv
+
+import (
+	"context"
+	"fmt"
+)
+
+// GetProperty fetches property and decodes it into T, handling mpv's numeric
+// widening (e.g. float64 -> int64/time.Duration) and JSON-decoding node
+// properties such as track-list or playlist into struct slices.
+func GetProperty[T any](ctx context.Context, c *Client, property string) (T, error) {
+	var zero T
+	value, err := c.GetProperty(ctx, property)
+	if err != nil {
+		return zero, err
+	}
+	v, ok := convertPropertyValue[T](value)
+	if !ok {
+		return zero, fmt.Errorf("mpv: property %q value %v is not convertible to %T", property, value, zero)
+	}
+	return v, nil
+}
+
+// SetProperty sets property to value.
+func SetProperty[T any](ctx context.Context, c *Client, property string, value T) error {
+	return c.SetProperty(ctx, property, value)
+}
+
+// ObserveProperty observes property and calls fn with its value decoded into
+// T every time it changes, skipping updates that can't be converted. The
+// returned rm function stops the observation.
+func ObserveProperty[T any](ctx context.Context, c *Client, property string, fn func(T)) (rm func() error, err error) {
+	return c.ObserveProperty(ctx, property, func(data any) {
+		if v, ok := convertPropertyValue[T](data); ok {
+			fn(v)
+		}
+	})
+}
@@ -0,0 +1,108 @@
+package mpv
+
+import (
+	"context"
+)
+
+// Invoker sends a single mpv command and returns its decoded result, exactly
+// like Client.Command. It's the type of both the terminal step of an
+// interceptor chain and the "next" function passed to each interceptor.
+type Invoker func(ctx context.Context, command string, args []any) (any, error)
+
+// CommandInterceptor wraps every command sent through Client.Command (and
+// Client.Batch), letting callers add cross-cutting behavior such as logging,
+// tracing, retries, or rate limiting without wrapping the Client type
+// itself. Call next to continue the chain; an interceptor that doesn't call
+// next short-circuits the command entirely.
+type CommandInterceptor func(ctx context.Context, command string, args []any, next Invoker) (any, error)
+
+// Use registers one or more interceptors, in the order given, around every
+// subsequent Command/Batch call. Interceptors registered earlier wrap those
+// registered later, so the first one added is the outermost. It has no
+// effect on CommandAsync/CommandAsyncMany; see UseAsync for those.
+func (c *Client) Use(interceptors ...CommandInterceptor) {
+	c.interceptorsMu.Lock()
+	defer c.interceptorsMu.Unlock()
+	c.interceptors = append(c.interceptors, interceptors...)
+}
+
+// invoke sends command through the registered interceptor chain before
+// actually writing it to mpv.
+func (c *Client) invoke(ctx context.Context, command string, args []any) (any, error) {
+	c.interceptorsMu.Lock()
+	chain := append([]CommandInterceptor(nil), c.interceptors...)
+	c.interceptorsMu.Unlock()
+
+	invoker := Invoker(func(ctx context.Context, command string, args []any) (any, error) {
+		return c.command(ctx, false, command, args...)
+	})
+
+	for i := len(chain) - 1; i >= 0; i-- {
+		interceptor := chain[i]
+		next := invoker
+		invoker = func(ctx context.Context, command string, args []any) (any, error) {
+			return interceptor(ctx, command, args, next)
+		}
+	}
+
+	return invoker(ctx, command, args)
+}
+
+// AsyncInvoker starts an mpv command without waiting for its response and
+// returns a Request for the caller to wait on, exactly like
+// Client.CommandAsync. It's the async counterpart to Invoker: the terminal
+// step of an async interceptor chain and the "next" function passed to each
+// AsyncCommandInterceptor.
+type AsyncInvoker func(ctx context.Context, command string, args []any) (Request, error)
+
+// AsyncCommandInterceptor wraps every command sent through
+// Client.CommandAsync (and CommandAsyncMany), letting callers add the same
+// kind of cross-cutting behavior as CommandInterceptor — logging, tracing,
+// retries, rate limiting — to the non-blocking path. Call next to continue
+// the chain; an interceptor that doesn't call next short-circuits the
+// command entirely.
+type AsyncCommandInterceptor func(ctx context.Context, command string, args []any, next AsyncInvoker) (Request, error)
+
+// UseAsync registers one or more interceptors, in the order given, around
+// every subsequent CommandAsync/CommandAsyncMany call. As with Use,
+// interceptors registered earlier wrap those registered later, so the first
+// one added is the outermost. CommandAsync and Command each have their own
+// interceptor chain, so an interceptor meant to apply to both must be
+// registered with both Use and UseAsync.
+func (c *Client) UseAsync(interceptors ...AsyncCommandInterceptor) {
+	c.asyncInterceptorsMu.Lock()
+	defer c.asyncInterceptorsMu.Unlock()
+	c.asyncInterceptors = append(c.asyncInterceptors, interceptors...)
+}
+
+// invokeAsync sends command through the registered async interceptor chain
+// before actually writing it to mpv.
+func (c *Client) invokeAsync(ctx context.Context, command string, args []any) (Request, error) {
+	c.asyncInterceptorsMu.Lock()
+	chain := append([]AsyncCommandInterceptor(nil), c.asyncInterceptors...)
+	c.asyncInterceptorsMu.Unlock()
+
+	invoker := AsyncInvoker(func(ctx context.Context, command string, args []any) (preq Request, err error) {
+		ctx, cancel := context.WithCancel(ctx)
+		req, err := c.ipc.startRequest(ctx, true, append([]any{command}, args...)...)
+		if err != nil {
+			cancel()
+			return
+		}
+		preq.ID = req.command.RequestID
+		preq.Response = req.resp
+		preq.Error = req.err
+		preq.Cancel = cancel
+		return
+	})
+
+	for i := len(chain) - 1; i >= 0; i-- {
+		interceptor := chain[i]
+		next := invoker
+		invoker = func(ctx context.Context, command string, args []any) (Request, error) {
+			return interceptor(ctx, command, args, next)
+		}
+	}
+
+	return invoker(ctx, command, args)
+}
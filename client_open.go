@@ -4,14 +4,25 @@ import (
 	"encoding/hex"
 	"fmt"
 	"math/rand/v2"
+	"net"
 	"os"
 	"sync/atomic"
 	"time"
 )
 
 type ClientOptions struct {
+	// SocketPath is either a bare OS path (a unix socket path, or a Windows
+	// named pipe path such as \\.\pipe\mpvsocket) or a scheme-prefixed
+	// address: "unix:///tmp/mpvsocket", "npipe://\\.\pipe\mpvsocket", or
+	// "tcp://127.0.0.1:9001" to connect to mpv's JSON IPC over TCP, e.g.
+	// across containers or machines.
 	SocketPath  string
 	DialTimeout time.Duration
+
+	// Reconnect enables automatic reconnection of the IPC transport. Leave
+	// nil to keep the default behavior of failing permanently when the
+	// connection is lost.
+	Reconnect *ReconnectPolicy
 }
 
 func (o *ClientOptions) applyDefaults() {
@@ -36,6 +47,21 @@ func OpenClientWithOptions(opts ClientOptions) (*Client, error) {
 	}
 
 	client := &Client{ipc: ipc}
+	client.Properties = Properties{c: client}
+
+	if opts.Reconnect != nil {
+		policy := *opts.Reconnect
+		policy.applyDefaults()
+		dial := func() (net.Conn, error) {
+			return dialTransport(opts.SocketPath, opts.DialTimeout)
+		}
+		ipc.enableReconnect(policy, dial, client.reapplyAfterReconnect)
+
+		if policy.KeepaliveInterval > 0 {
+			go client.runKeepalive(policy.KeepaliveInterval, policy.KeepaliveTimeout)
+		}
+	}
+
 	go client.acceptEvents()
 	return client, nil
 }
@@ -0,0 +1,77 @@
+package mpv
+
+import (
+	"context"
+	"time"
+)
+
+// Track is one entry of the track-list property.
+// See https://mpv.io/manual/stable/#command-interface-track-list.
+type Track struct {
+	ID       int64  `json:"id"`
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Lang     string `json:"lang"`
+	Codec    string `json:"codec"`
+	Selected bool   `json:"selected"`
+	Default  bool   `json:"default"`
+	External bool   `json:"external"`
+}
+
+// PlaylistEntry is one entry of the playlist property.
+// See https://mpv.io/manual/stable/#command-interface-playlist.
+type PlaylistEntry struct {
+	ID       int64  `json:"id"`
+	Filename string `json:"filename"`
+	Title    string `json:"title"`
+	Current  bool   `json:"current"`
+	Playing  bool   `json:"playing"`
+}
+
+// Chapter is one entry of the chapter-list property.
+// See https://mpv.io/manual/stable/#command-interface-chapter-list.
+type Chapter struct {
+	Title string  `json:"title"`
+	Time  float64 `json:"time"`
+}
+
+// Properties exposes typed accessors for well-known mpv properties so
+// callers get autocomplete-driven discovery instead of hunting for property
+// name strings. It's reachable as Client.Properties; anything not listed
+// here is still available through Client.GetProperty or the generic
+// GetProperty function.
+type Properties struct {
+	c *Client
+}
+
+func (p Properties) TimePos(ctx context.Context) (time.Duration, error) {
+	return GetProperty[time.Duration](ctx, p.c, "time-pos")
+}
+
+func (p Properties) Duration(ctx context.Context) (time.Duration, error) {
+	return GetProperty[time.Duration](ctx, p.c, "duration")
+}
+
+func (p Properties) Volume(ctx context.Context) (float64, error) {
+	return GetProperty[float64](ctx, p.c, "volume")
+}
+
+func (p Properties) Pause(ctx context.Context) (bool, error) {
+	return GetProperty[bool](ctx, p.c, "pause")
+}
+
+func (p Properties) Filename(ctx context.Context) (string, error) {
+	return GetProperty[string](ctx, p.c, "filename")
+}
+
+func (p Properties) TrackList(ctx context.Context) ([]Track, error) {
+	return GetProperty[[]Track](ctx, p.c, "track-list")
+}
+
+func (p Properties) Playlist(ctx context.Context) ([]PlaylistEntry, error) {
+	return GetProperty[[]PlaylistEntry](ctx, p.c, "playlist")
+}
+
+func (p Properties) ChapterList(ctx context.Context) ([]Chapter, error) {
+	return GetProperty[[]Chapter](ctx, p.c, "chapter-list")
+}
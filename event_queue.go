@@ -0,0 +1,199 @@
+package mpv
+
+// defaultEventQueueCapacity is the queue size used for event handlers that
+// don't specify one explicitly via WithQueueCapacity.
+const defaultEventQueueCapacity = 64
+
+// EventOverflowPolicy controls what happens when an event handler's queue is
+// full and a new event arrives for it.
+type EventOverflowPolicy int
+
+const (
+	// OverflowBlock blocks the event dispatcher until the handler's queue
+	// has room. This guarantees delivery but means a slow handler can stall
+	// delivery to every other handler, and since dispatch runs inline in
+	// the client's single event-acceptance goroutine, it also stalls the
+	// shared IPC read loop feeding it. It is never the default; opt into it
+	// explicitly via WithOverflowPolicy only for a handler you are certain
+	// keeps up.
+	OverflowBlock EventOverflowPolicy = iota
+	// OverflowDropOldest discards the oldest queued event to make room for
+	// the new one.
+	OverflowDropOldest
+	// OverflowDropNewest discards the incoming event, leaving the queue
+	// untouched.
+	OverflowDropNewest
+	// OverflowError removes the handler from the client the first time its
+	// queue overflows.
+	OverflowError
+)
+
+type eventHandlerOptions struct {
+	capacity int
+	overflow EventOverflowPolicy
+	onDrop   func(map[string]any)
+}
+
+func defaultEventHandlerOptions() eventHandlerOptions {
+	return eventHandlerOptions{
+		capacity: defaultEventQueueCapacity,
+		// OverflowDropOldest, not OverflowBlock: dispatch runs inline in the
+		// client's event-acceptance goroutine, which in turn is what drains
+		// the shared IPC read loop. A handler that blocks there (including
+		// the one ObserveProperty installs internally for every observed
+		// property) would stall delivery to every other handler and every
+		// in-flight Command/CommandAsync response, not just its own events.
+		overflow: OverflowDropOldest,
+	}
+}
+
+// EventHandlerOption configures the queue behavior of an event handler
+// registered via AddEventHandler.
+type EventHandlerOption func(*eventHandlerOptions)
+
+// WithQueueCapacity sets the number of events an async handler may have
+// buffered before its overflow policy kicks in.
+func WithQueueCapacity(capacity int) EventHandlerOption {
+	return func(o *eventHandlerOptions) {
+		o.capacity = capacity
+	}
+}
+
+// WithOverflowPolicy sets what happens when a handler's queue is full.
+func WithOverflowPolicy(policy EventOverflowPolicy) EventHandlerOption {
+	return func(o *eventHandlerOptions) {
+		o.overflow = policy
+	}
+}
+
+// WithDropHandler registers a callback invoked with the dropped event every
+// time the handler's overflow policy causes an event to be discarded. It is
+// also invoked, with a nil event, the moment the handler is removed because
+// of OverflowError.
+func WithDropHandler(fn func(map[string]any)) EventHandlerOption {
+	return func(o *eventHandlerOptions) {
+		o.onDrop = fn
+	}
+}
+
+type eventHandler struct {
+	sync bool
+	fn   func(map[string]any)
+
+	overflow EventOverflowPolicy
+	onDrop   func(map[string]any)
+
+	// reportDrop, set by the owning client, is called every time an event is
+	// dropped so the total survives this handler being removed (see
+	// MPVClient.DroppedEvents/Client.DroppedEvents).
+	reportDrop func()
+
+	queue chan map[string]any
+	done  chan struct{}
+}
+
+func newEventHandler(sync bool, fn func(map[string]any), opts ...EventHandlerOption) *eventHandler {
+	o := defaultEventHandlerOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	h := &eventHandler{
+		sync:     sync,
+		fn:       fn,
+		overflow: o.overflow,
+		onDrop:   o.onDrop,
+	}
+
+	if !sync {
+		h.queue = make(chan map[string]any, o.capacity)
+		h.done = make(chan struct{})
+		go h.run()
+	}
+
+	return h
+}
+
+func (h *eventHandler) run() {
+	for {
+		select {
+		case event, ok := <-h.queue:
+			if !ok {
+				return
+			}
+			h.fn(event)
+		case <-h.done:
+			return
+		}
+	}
+}
+
+// dispatch delivers event to the handler, applying its overflow policy if
+// necessary. It reports whether the handler should be removed as a result
+// (only ever true under OverflowError).
+func (h *eventHandler) dispatch(event map[string]any) (remove bool) {
+	if h.sync {
+		h.fn(event)
+		return false
+	}
+
+	select {
+	case h.queue <- event:
+		return false
+	case <-h.done:
+		return false
+	default:
+	}
+
+	switch h.overflow {
+	case OverflowBlock:
+		select {
+		case h.queue <- event:
+		case <-h.done:
+		}
+		return false
+	case OverflowDropOldest:
+		var evicted map[string]any
+		select {
+		case evicted = <-h.queue:
+		default:
+		}
+		select {
+		case h.queue <- event:
+		default:
+		}
+		h.recordDrop(evicted)
+		return false
+	case OverflowDropNewest:
+		h.recordDrop(event)
+		return false
+	case OverflowError:
+		h.recordDrop(event)
+		if h.onDrop != nil {
+			h.onDrop(nil)
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+func (h *eventHandler) recordDrop(event map[string]any) {
+	if h.reportDrop != nil {
+		h.reportDrop()
+	}
+	if h.onDrop != nil {
+		h.onDrop(event)
+	}
+}
+
+func (h *eventHandler) close() {
+	if h.done == nil {
+		return
+	}
+	select {
+	case <-h.done:
+	default:
+		close(h.done)
+	}
+}
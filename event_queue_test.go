@@ -0,0 +1,214 @@
+package mpv
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func ev(tag string) map[string]any { return map[string]any{"tag": tag} }
+
+// blockOnFirst returns a handler fn that, on its first call, closes started
+// and then waits for release before recording the event. Every later call
+// records immediately. This lets a test fill a handler's queue while the
+// consumer goroutine (run) is known to be parked on that first call, rather
+// than racing it.
+func blockOnFirst(started chan<- struct{}, release <-chan struct{}) (fn func(map[string]any), received func() []map[string]any) {
+	var mu sync.Mutex
+	var got []map[string]any
+	first := true
+	fn = func(event map[string]any) {
+		if first {
+			first = false
+			close(started)
+			<-release
+		}
+		mu.Lock()
+		got = append(got, event)
+		mu.Unlock()
+	}
+	received = func() []map[string]any {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]map[string]any(nil), got...)
+	}
+	return fn, received
+}
+
+func waitForCount(t *testing.T, get func() []map[string]any, n int) []map[string]any {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for {
+		got := get()
+		if len(got) >= n {
+			return got
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %d delivered events, got %d", n, len(got))
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestEventHandlerOverflowDropOldest(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	fn, received := blockOnFirst(started, release)
+
+	var dropped []map[string]any
+	var mu sync.Mutex
+	onDrop := func(e map[string]any) {
+		mu.Lock()
+		dropped = append(dropped, e)
+		mu.Unlock()
+	}
+
+	h := newEventHandler(false, fn, WithQueueCapacity(2), WithOverflowPolicy(OverflowDropOldest), WithDropHandler(onDrop))
+	defer h.close()
+
+	h.dispatch(ev("e1"))
+	<-started // e1 is now being handled; the queue is empty again.
+
+	h.dispatch(ev("e2"))
+	h.dispatch(ev("e3")) // queue is now full: [e2, e3]
+
+	if remove := h.dispatch(ev("e4")); remove {
+		t.Fatalf("dispatch reported remove=true under OverflowDropOldest")
+	}
+
+	mu.Lock()
+	if len(dropped) != 1 || dropped[0]["tag"] != "e2" {
+		t.Fatalf("expected e2 to be dropped as the oldest queued event, got %v", dropped)
+	}
+	mu.Unlock()
+
+	close(release)
+	got := waitForCount(t, received, 3)
+	want := []string{"e1", "e3", "e4"}
+	for i, tag := range want {
+		if got[i]["tag"] != tag {
+			t.Fatalf("delivered[%d] = %v, want tag %q", i, got[i], tag)
+		}
+	}
+}
+
+func TestEventHandlerOverflowDropNewest(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	fn, received := blockOnFirst(started, release)
+
+	var dropped []map[string]any
+	var mu sync.Mutex
+	onDrop := func(e map[string]any) {
+		mu.Lock()
+		dropped = append(dropped, e)
+		mu.Unlock()
+	}
+
+	h := newEventHandler(false, fn, WithQueueCapacity(2), WithOverflowPolicy(OverflowDropNewest), WithDropHandler(onDrop))
+	defer h.close()
+
+	h.dispatch(ev("e1"))
+	<-started
+
+	h.dispatch(ev("e2"))
+	h.dispatch(ev("e3")) // queue is now full: [e2, e3]
+
+	if remove := h.dispatch(ev("e4")); remove {
+		t.Fatalf("dispatch reported remove=true under OverflowDropNewest")
+	}
+
+	mu.Lock()
+	if len(dropped) != 1 || dropped[0]["tag"] != "e4" {
+		t.Fatalf("expected e4 (the incoming event) to be dropped, got %v", dropped)
+	}
+	mu.Unlock()
+
+	close(release)
+	got := waitForCount(t, received, 3)
+	want := []string{"e1", "e2", "e3"}
+	for i, tag := range want {
+		if got[i]["tag"] != tag {
+			t.Fatalf("delivered[%d] = %v, want tag %q", i, got[i], tag)
+		}
+	}
+}
+
+func TestEventHandlerOverflowError(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	fn, received := blockOnFirst(started, release)
+
+	var dropped []map[string]any
+	var mu sync.Mutex
+	onDrop := func(e map[string]any) {
+		mu.Lock()
+		dropped = append(dropped, e)
+		mu.Unlock()
+	}
+
+	h := newEventHandler(false, fn, WithQueueCapacity(2), WithOverflowPolicy(OverflowError), WithDropHandler(onDrop))
+	defer h.close()
+
+	h.dispatch(ev("e1"))
+	<-started
+
+	h.dispatch(ev("e2"))
+	h.dispatch(ev("e3")) // queue is now full: [e2, e3]
+
+	if remove := h.dispatch(ev("e4")); !remove {
+		t.Fatalf("dispatch reported remove=false under OverflowError")
+	}
+
+	mu.Lock()
+	if len(dropped) != 2 || dropped[0]["tag"] != "e4" || dropped[1] != nil {
+		t.Fatalf("expected onDrop(e4) for the drop and onDrop(nil) for the removal, got %v", dropped)
+	}
+	mu.Unlock()
+
+	close(release)
+	waitForCount(t, received, 3)
+}
+
+func TestEventHandlerOverflowBlock(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	fn, received := blockOnFirst(started, release)
+
+	h := newEventHandler(false, fn, WithQueueCapacity(2), WithOverflowPolicy(OverflowBlock))
+	defer h.close()
+
+	h.dispatch(ev("e1"))
+	<-started
+
+	h.dispatch(ev("e2"))
+	h.dispatch(ev("e3")) // queue is now full: [e2, e3]
+
+	dispatchDone := make(chan struct{})
+	go func() {
+		h.dispatch(ev("e4")) // must block until run() drains room for it
+		close(dispatchDone)
+	}()
+
+	select {
+	case <-dispatchDone:
+		t.Fatal("dispatch returned before the queue had room, OverflowBlock should have blocked")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(release)
+
+	select {
+	case <-dispatchDone:
+	case <-time.After(time.Second):
+		t.Fatal("dispatch never unblocked once the queue drained")
+	}
+
+	got := waitForCount(t, received, 4)
+	want := []string{"e1", "e2", "e3", "e4"}
+	for i, tag := range want {
+		if got[i]["tag"] != tag {
+			t.Fatalf("delivered[%d] = %v, want tag %q", i, got[i], tag)
+		}
+	}
+}
@@ -40,6 +40,8 @@ type request struct {
 type ipc struct {
 	conn    net.Conn
 	scanner *bufio.Scanner
+	connMu  sync.Mutex // guards conn/scanner across reconnects
+	writeMu sync.Mutex // serializes conn.Write calls, e.g. writeLoop vs. replayPending during a reconnect
 
 	requestID       atomic.Int64
 	pendingRequests *sync.Map
@@ -51,6 +53,13 @@ type ipc struct {
 	closingWg sync.WaitGroup
 	closeCh   chan struct{}
 	closeMu   sync.Mutex
+
+	// Reconnect support; reconnect is nil unless enableReconnect was called.
+	reconnect    *ReconnectPolicy
+	dial         dialFunc
+	onReconnect  func()
+	reconnMu     sync.Mutex
+	reconnecting bool
 }
 
 func newIPC(socket net.Conn) *ipc {
@@ -104,17 +113,32 @@ func (i *ipc) startRequest(ctx context.Context, async bool, args ...any) (req re
 }
 
 func (i *ipc) read() ([]byte, error) {
-	if !i.scanner.Scan() {
-		if err := i.scanner.Err(); err != nil {
+	i.connMu.Lock()
+	scanner := i.scanner
+	i.connMu.Unlock()
+
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
 			return nil, err
 		}
 		return nil, ErrClosed
 	}
-	return i.scanner.Bytes(), nil
+	return scanner.Bytes(), nil
 }
 
+// write sends data over the current connection. It holds writeMu for the
+// full read-conn-then-write, not just the conn lookup, so a resend from
+// replayPending during a reconnect can never interleave with a concurrent
+// writeLoop write and corrupt an IPC frame.
 func (i *ipc) write(data []byte) error {
-	_, err := i.conn.Write(data)
+	i.writeMu.Lock()
+	defer i.writeMu.Unlock()
+
+	i.connMu.Lock()
+	conn := i.conn
+	i.connMu.Unlock()
+
+	_, err := conn.Write(data)
 	return err
 }
 
@@ -135,14 +159,20 @@ func (i *ipc) close() error {
 		return nil
 	default:
 	}
+	// Stop accepting new requests and mark this as a deliberate close before
+	// touching the connection, so a concurrent reconnect-enabled read/write
+	// loop doesn't mistake it for a transient disconnect.
+	i.closing = true
+
 	// Close the connection and signal the read loop to stop.
-	err := i.conn.Close()
+	i.connMu.Lock()
+	conn := i.conn
+	i.connMu.Unlock()
+	err := conn.Close()
 	if err != nil {
 		i.closeMu.Unlock()
 		return fmt.Errorf("ipc: failed to close connection: %w", err)
 	}
-	// Stop accepting new requests.
-	i.closing = true
 	// Signal the write loop to stop.
 	close(i.closeCh)
 	// Unlock the mutex, allows for subsequent calls to Close.
@@ -185,6 +215,12 @@ func (i *ipc) writeLoop() {
 			i.pendingRequests.Store(req.command.RequestID, req)
 
 			if err := i.writeJSON(req.command); err != nil {
+				if i.reconnect != nil && !i.closing {
+					// Leave the request pending; reconnectLoop will replay it
+					// once the connection is re-established.
+					i.handleConnError()
+					continue
+				}
 				select {
 				case req.err <- err:
 				case <-req.ctx.Done():
@@ -202,6 +238,9 @@ func (i *ipc) readLoop() {
 	for {
 		data, err := i.read()
 		if err != nil {
+			if i.reconnect != nil && !i.closing {
+				i.handleConnError()
+			}
 			return
 		}
 
@@ -212,12 +251,13 @@ func (i *ipc) readLoop() {
 
 		switch {
 		case event["event"] != nil:
+			// Block until the event is accepted rather than dropping it:
+			// per-subscriber queues (see eventHandler) are responsible for
+			// applying backpressure or an overflow policy, not the IPC layer.
 			select {
 			case i.events <- event:
 			case <-i.closeCh:
 				return
-			default:
-				// Drop event if no one is listening
 			}
 		case event["error"] != nil:
 			i.handleResponse(event)
@@ -0,0 +1,240 @@
+package mpv
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"time"
+)
+
+// ErrDisconnected is surfaced to in-flight requests when the IPC connection
+// is lost, reconnecting is enabled, and every reconnect attempt allowed by
+// ReconnectPolicy.MaxRetries has failed. Without reconnecting, a lost
+// connection does not produce it. Unlike ErrClosed, it does not mean Close
+// was called.
+var ErrDisconnected = errors.New("ipc: disconnected")
+
+// ReconnectPolicy enables automatic reconnection of the IPC transport. When
+// set on ClientOptions, a broken socket/pipe is re-dialed with exponential
+// backoff instead of permanently failing the client: active observe_property
+// subscriptions are replayed, and requests still pending at the time of the
+// disconnect are resent once the connection comes back (as long as their
+// context hasn't expired).
+type ReconnectPolicy struct {
+	// InitialBackoff is the delay before the first redial attempt.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between redial attempts.
+	MaxBackoff time.Duration
+	// BackoffFactor is multiplied into the delay after each failed attempt.
+	BackoffFactor float64
+	// MaxRetries is the number of redial attempts before giving up and
+	// closing the client. Zero means retry forever.
+	MaxRetries int
+	// KeepaliveInterval, if non-zero, sends a cheap no-op request at this
+	// interval to detect half-open connections. Zero disables keepalives.
+	KeepaliveInterval time.Duration
+	// KeepaliveTimeout is how long a keepalive may go unanswered before the
+	// connection is considered broken and reconnected early.
+	KeepaliveTimeout time.Duration
+}
+
+func (p *ReconnectPolicy) applyDefaults() {
+	if p.InitialBackoff == 0 {
+		p.InitialBackoff = 250 * time.Millisecond
+	}
+	if p.MaxBackoff == 0 {
+		p.MaxBackoff = 10 * time.Second
+	}
+	if p.BackoffFactor == 0 {
+		p.BackoffFactor = 2
+	}
+	if p.KeepaliveTimeout == 0 {
+		p.KeepaliveTimeout = 5 * time.Second
+	}
+}
+
+// dialFunc redials the transport. The client constructors supply it as a
+// closure over the OS-specific dialTransport, so the ipc package stays
+// agnostic of unix sockets, named pipes, or TCP.
+type dialFunc func() (net.Conn, error)
+
+// enableReconnect arms ipc for automatic reconnection. dial is used to
+// re-establish the connection, and onReconnect (if non-nil) is called after
+// every successful reconnect, once pending requests have been replayed, so
+// callers can re-apply state such as observe_property subscriptions.
+func (i *ipc) enableReconnect(policy ReconnectPolicy, dial dialFunc, onReconnect func()) {
+	policy.applyDefaults()
+	i.reconnect = &policy
+	i.dial = dial
+	i.onReconnect = onReconnect
+}
+
+// handleConnError is invoked by the read/write loops when an I/O error
+// occurs. If reconnecting isn't enabled (or the ipc is being closed for
+// good), it falls back to the old behavior of tearing everything down.
+func (i *ipc) handleConnError() {
+	if i.closing || i.reconnect == nil {
+		i.close()
+		return
+	}
+
+	i.reconnMu.Lock()
+	if i.reconnecting {
+		i.reconnMu.Unlock()
+		return
+	}
+	i.reconnecting = true
+	i.reconnMu.Unlock()
+
+	go i.reconnectLoop()
+}
+
+// forceReconnect closes the current connection to trigger the normal
+// handleConnError/reconnect path. It's used by the keepalive watchdog when a
+// connection looks half-open rather than outright broken.
+func (i *ipc) forceReconnect() {
+	if i.reconnect == nil || i.closing {
+		return
+	}
+	i.connMu.Lock()
+	conn := i.conn
+	i.connMu.Unlock()
+	if conn != nil {
+		conn.Close()
+	}
+}
+
+// sleepOrClosed waits for backoff to elapse, returning true early if closeCh
+// fires in the meantime so a pending Close isn't held up by a redial delay.
+func (i *ipc) sleepOrClosed(backoff time.Duration) (closed bool) {
+	select {
+	case <-time.After(backoff):
+		return false
+	case <-i.closeCh:
+		return true
+	}
+}
+
+func (i *ipc) reconnectLoop() {
+	backoff := i.reconnect.InitialBackoff
+	attempt := 0
+
+	for {
+		select {
+		case <-i.closeCh:
+			return
+		default:
+		}
+		if i.reconnect.MaxRetries > 0 && attempt >= i.reconnect.MaxRetries {
+			i.failPending(ErrDisconnected)
+			i.close()
+			return
+		}
+
+		conn, err := i.dial()
+		if err != nil {
+			attempt++
+			if i.sleepOrClosed(backoff) {
+				return
+			}
+			backoff = time.Duration(float64(backoff) * i.reconnect.BackoffFactor)
+			if backoff > i.reconnect.MaxBackoff {
+				backoff = i.reconnect.MaxBackoff
+			}
+			continue
+		}
+
+		// Close() may have run while dial was in flight; bail out before the
+		// freshly-dialed connection is adopted by anything.
+		select {
+		case <-i.closeCh:
+			conn.Close()
+			return
+		default:
+		}
+
+		i.connMu.Lock()
+		i.conn = conn
+		i.scanner = bufio.NewScanner(conn)
+		i.connMu.Unlock()
+
+		if err := i.replayPending(); err != nil {
+			// The new connection died before every pending request could be
+			// resent; treat it as another failed attempt and redial.
+			conn.Close()
+			attempt++
+			if i.sleepOrClosed(backoff) {
+				return
+			}
+			backoff = time.Duration(float64(backoff) * i.reconnect.BackoffFactor)
+			if backoff > i.reconnect.MaxBackoff {
+				backoff = i.reconnect.MaxBackoff
+			}
+			continue
+		}
+
+		i.reconnMu.Lock()
+		i.reconnecting = false
+		i.reconnMu.Unlock()
+
+		// Gate spawning readLoop behind closeMu so it can never race a
+		// concurrent Close(): either this Add happens before close(closeCh)
+		// (and closingWg.Wait then correctly waits on the new readLoop too),
+		// or it observes closeCh already closed and backs off, leaving the
+		// already-closed i.events/i.outgoing channels untouched.
+		i.closeMu.Lock()
+		select {
+		case <-i.closeCh:
+			i.closeMu.Unlock()
+			conn.Close()
+			return
+		default:
+		}
+		i.closingWg.Add(1)
+		i.closeMu.Unlock()
+
+		go i.readLoop()
+
+		if i.onReconnect != nil {
+			i.onReconnect()
+		}
+		return
+	}
+}
+
+// replayPending resends every request still awaiting a response over the
+// freshly-dialed connection. Requests whose context has already expired are
+// failed instead of replayed. It stops at the first write failure, which the
+// caller treats as a sign the new connection is already broken.
+func (i *ipc) replayPending() error {
+	var writeErr error
+	i.pendingRequests.Range(func(key, v any) bool {
+		req := v.(request)
+		if req.ctx.Err() != nil {
+			i.pendingRequests.Delete(key)
+			select {
+			case req.err <- req.ctx.Err():
+			default:
+			}
+			return true
+		}
+		if err := i.writeJSON(req.command); err != nil {
+			writeErr = err
+			return false
+		}
+		return true
+	})
+	return writeErr
+}
+
+func (i *ipc) failPending(err error) {
+	i.pendingRequests.Range(func(key, v any) bool {
+		req := v.(request)
+		select {
+		case req.err <- err:
+		default:
+		}
+		i.pendingRequests.Delete(key)
+		return true
+	})
+}
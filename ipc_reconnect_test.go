@@ -0,0 +1,184 @@
+package mpv
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeMPVServer is a minimal stand-in for mpv's JSON IPC socket, good enough
+// to drive ipc's reconnect path: it accepts connections, answers commands
+// with a success response, records which commands each connection saw, and
+// lets a test single out one command to go unanswered (simulating mpv never
+// getting around to it before the connection dies) and kill the current
+// connection outright (simulating mpv restarting).
+type fakeMPVServer struct {
+	ln net.Listener
+
+	mu       sync.Mutex
+	conns    []net.Conn
+	commands [][]string // commands[i] is every command name serve(i) saw
+	swallow  func(idx int, args []any) bool
+}
+
+func newFakeMPVServer(t *testing.T) *fakeMPVServer {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	s := &fakeMPVServer{ln: ln}
+	go s.acceptLoop()
+	t.Cleanup(func() { s.ln.Close() })
+	return s
+}
+
+func (s *fakeMPVServer) addr() string { return s.ln.Addr().String() }
+
+func (s *fakeMPVServer) acceptLoop() {
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		idx := len(s.conns)
+		s.conns = append(s.conns, conn)
+		s.commands = append(s.commands, nil)
+		s.mu.Unlock()
+		go s.serve(conn, idx)
+	}
+}
+
+func (s *fakeMPVServer) serve(conn net.Conn, idx int) {
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		var cmd mpvCommand
+		if err := json.Unmarshal(scanner.Bytes(), &cmd); err != nil {
+			continue
+		}
+		name, _ := cmd.Command[0].(string)
+
+		s.mu.Lock()
+		s.commands[idx] = append(s.commands[idx], name)
+		swallow := s.swallow
+		s.mu.Unlock()
+
+		if swallow != nil && swallow(idx, cmd.Command[1:]) {
+			continue // never answer this one
+		}
+
+		resp := Response{Error: "success", RequestID: cmd.RequestID}
+		data, _ := json.Marshal(resp)
+		conn.Write(append(data, '\n'))
+	}
+}
+
+// killConn closes connection idx (0-based, in accept order) so its client
+// observes a read error and, with reconnect enabled, redials.
+func (s *fakeMPVServer) killConn(idx int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if idx < len(s.conns) {
+		s.conns[idx].Close()
+	}
+}
+
+func (s *fakeMPVServer) commandsOn(idx int) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.commands[idx]...)
+}
+
+func contains(haystack []string, needle string) bool {
+	for _, s := range haystack {
+		if s == needle {
+			return true
+		}
+	}
+	return false
+}
+
+// TestIPCReconnectReplaysPendingAndReappliesSubscriptions kills the IPC
+// connection while a request is still in flight and a property observer is
+// active, then checks that the request is replayed to completion and the
+// observer is re-subscribed on the new connection, exactly as
+// reapplyAfterReconnect promises.
+func TestIPCReconnectReplaysPendingAndReappliesSubscriptions(t *testing.T) {
+	server := newFakeMPVServer(t)
+
+	// Only the first connection swallows "get_property"/"volume", so that
+	// request is still pending when the connection is killed but gets a
+	// real answer once replayed on the reconnected connection.
+	server.swallow = func(idx int, args []any) bool {
+		return idx == 0 && len(args) > 0 && fmt.Sprint(args[0]) == "volume"
+	}
+
+	client, err := OpenClientWithOptions(ClientOptions{
+		SocketPath: "tcp://" + server.addr(),
+		Reconnect: &ReconnectPolicy{
+			InitialBackoff: 5 * time.Millisecond,
+			MaxBackoff:     20 * time.Millisecond,
+			BackoffFactor:  2,
+		},
+	})
+	if err != nil {
+		t.Fatalf("OpenClientWithOptions: %v", err)
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	if _, err := client.ObserveProperty(ctx, "pause", func(any) {}); err != nil {
+		t.Fatalf("ObserveProperty: %v", err)
+	}
+
+	req, err := client.CommandAsync(ctx, "get_property", "volume")
+	if err != nil {
+		t.Fatalf("CommandAsync: %v", err)
+	}
+
+	// Give the command a moment to actually reach the (swallowing) server
+	// before killing the connection out from under it, so it's genuinely
+	// pending rather than never sent at all.
+	time.Sleep(20 * time.Millisecond)
+	server.killConn(0)
+
+	// The second connection answers everything, so the replayed request
+	// should now succeed.
+	select {
+	case resp := <-req.Response:
+		if !resp.Success() {
+			t.Fatalf("expected success after replay, got %+v", resp)
+		}
+	case err := <-req.Error:
+		t.Fatalf("replayed request failed: %v", err)
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for the replayed request")
+	}
+
+	// Wait for the second connection to be established and used.
+	deadline := time.Now().Add(time.Second)
+	for {
+		server.mu.Lock()
+		n := len(server.conns)
+		server.mu.Unlock()
+		if n >= 2 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for a second connection")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if !contains(server.commandsOn(1), "observe_property") {
+		t.Fatalf("observe_property was not reissued on the new connection, saw %v", server.commandsOn(1))
+	}
+}
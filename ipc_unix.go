@@ -3,14 +3,30 @@
 package mpv
 
 import (
+	"fmt"
 	"net"
 	"time"
 )
 
 var defaultSocketPath = "/tmp/mpvsocket"
 
+// dialTransport dials address, which may be a bare unix socket path or a
+// scheme-prefixed address (unix://..., tcp://host:port) as described on
+// ClientOptions.SocketPath.
+func dialTransport(address string, timeout time.Duration) (net.Conn, error) {
+	scheme, target := parseAddress(address)
+	switch scheme {
+	case "", "unix":
+		return net.DialTimeout("unix", target, timeout)
+	case "tcp":
+		return net.DialTimeout("tcp", target, timeout)
+	default:
+		return nil, fmt.Errorf("mpv: unsupported socket address scheme %q", scheme)
+	}
+}
+
 func openIPC(socketPath string, timeout time.Duration) (*ipc, error) {
-	conn, err := net.DialTimeout("unix", socketPath, timeout)
+	conn, err := dialTransport(socketPath, timeout)
 	if err != nil {
 		return nil, err
 	}
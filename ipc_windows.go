@@ -3,6 +3,8 @@
 package mpv
 
 import (
+	"fmt"
+	"net"
 	"time"
 
 	"github.com/natefinch/npipe"
@@ -10,8 +12,23 @@ import (
 
 var defaultSocketPath = `\\.\pipe\mpvsocket`
 
+// dialTransport dials address, which may be a bare named pipe path or a
+// scheme-prefixed address (npipe://..., tcp://host:port) as described on
+// ClientOptions.SocketPath.
+func dialTransport(address string, timeout time.Duration) (net.Conn, error) {
+	scheme, target := parseAddress(address)
+	switch scheme {
+	case "", "npipe":
+		return npipe.DialTimeout(target, timeout)
+	case "tcp":
+		return net.DialTimeout("tcp", target, timeout)
+	default:
+		return nil, fmt.Errorf("mpv: unsupported socket address scheme %q", scheme)
+	}
+}
+
 func openIPC(socketPath string, timeout time.Duration) (*ipc, error) {
-	conn, err := npipe.DialTimeout(socketPath, timeout)
+	conn, err := dialTransport(socketPath, timeout)
 	if err != nil {
 		return nil, err
 	}
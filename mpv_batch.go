@@ -0,0 +1,97 @@
+package mpv
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// Batch accumulates commands to send to MPV in a single pipelined burst
+// rather than one IPC round-trip at a time. Build one with MPVClient.Batch,
+// queue commands with its chainable methods, then call Do to send them all
+// and wait for every response.
+type Batch struct {
+	client   *MPVClient
+	commands [][]any
+}
+
+// Batch returns a new, empty Batch bound to c.
+func (c *MPVClient) Batch() *Batch {
+	return &Batch{client: c}
+}
+
+// Command queues an arbitrary mpv command. See
+// https://mpv.io/manual/stable/#list-of-input-commands.
+func (b *Batch) Command(command string, args ...any) *Batch {
+	b.commands = append(b.commands, append([]any{command}, args...))
+	return b
+}
+
+func (b *Batch) SetProperty(property string, value any) *Batch {
+	return b.Command("set_property", property, value)
+}
+
+func (b *Batch) Seek(position float64, flags ...SeekFlag) *Batch {
+	if len(flags) == 0 {
+		return b.Command("seek", position)
+	}
+	flag := strings.Builder{}
+	for i, f := range flags {
+		if i > 0 {
+			flag.WriteRune('+')
+		}
+		flag.WriteString(string(f))
+	}
+	return b.Command("seek", position, flag.String())
+}
+
+func (b *Batch) LoadFile(file string, mode LoadFileMode) *Batch {
+	return b.Command("loadfile", file, string(mode))
+}
+
+// Do sends every queued command in a single burst, without waiting for each
+// response before writing the next command, then waits for all of them to
+// complete. Responses are returned in the order the commands were queued. If
+// any request fails to start, Do returns immediately with that error; if a
+// request that was sent fails, Do still waits for the rest and returns the
+// first error alongside the responses collected so far.
+func (b *Batch) Do(ctx context.Context) ([]Response, error) {
+	n := len(b.commands)
+	if n == 0 {
+		return nil, nil
+	}
+
+	reqs := make([]request, n)
+	for i, args := range b.commands {
+		req, err := b.client.ipc.startRequest(ctx, false, args...)
+		if err != nil {
+			return nil, err
+		}
+		reqs[i] = req
+	}
+
+	responses := make([]Response, n)
+	errs := make([]error, n)
+
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i, req := range reqs {
+		go func(i int, req request) {
+			defer wg.Done()
+			select {
+			case resp := <-req.resp:
+				responses[i] = resp
+			case err := <-req.err:
+				errs[i] = err
+			}
+		}(i, req)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return responses, err
+		}
+	}
+	return responses, nil
+}
@@ -1,42 +1,31 @@
 package mpv
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
-type (
-	LoadFileMode string
-	SeekFlag     string
-)
-
-const (
-	LoadFileModeReplace    LoadFileMode = "replace"
-	LoadFileModeAppend     LoadFileMode = "append"
-	LoadFileModeAppendPlay LoadFileMode = "append-play"
-)
-
-const (
-	SeekFlagRelative        SeekFlag = "relative"
-	SeekFlagAbsolute        SeekFlag = "absolute"
-	SeekFlagExact           SeekFlag = "exact"
-	SeekFlagKeyframes       SeekFlag = "keyframes"
-	SeekFlagRelativePercent SeekFlag = "relative-percent"
-	SeekFlagAbsolutePercent SeekFlag = "absolute-percent"
-)
-
-type eventHandler struct {
-	sync bool
-	fn   func(map[string]any)
-}
+// LoadFileMode and SeekFlag, along with their values, are declared in
+// client.go; MPVClient shares the same constants since they describe mpv's
+// own loadfile/seek argument strings rather than anything specific to
+// either client implementation.
 
 type MPVClient struct {
 	ipc             *ipc
 	eventHandlersMu sync.Mutex
 	eventHandlers   []*eventHandler
 	observerID      atomic.Int64
+	droppedEvents   atomic.Int64
+
+	// subsMu/subs back reconnect support: when the transport re-dials,
+	// reapplyAfterReconnect replays them so observers registered through
+	// ObserveProperty survive an mpv restart.
+	subsMu sync.Mutex
+	subs   map[int64]string
 }
 
 func (c *MPVClient) Close() error {
@@ -204,8 +193,18 @@ func (c *MPVClient) ObserveProperty(property string, fn func(any)) (rm func() er
 		return nil, fmt.Errorf("failed to observe property: %w", err)
 	}
 
+	c.subsMu.Lock()
+	if c.subs == nil {
+		c.subs = make(map[int64]string)
+	}
+	c.subs[observerID] = property
+	c.subsMu.Unlock()
+
 	return func() error {
 		rmEventHandler()
+		c.subsMu.Lock()
+		delete(c.subs, observerID)
+		c.subsMu.Unlock()
 		if _, err := c.CommandAsync("unobserve_property", observerID); err != nil {
 			return fmt.Errorf("failed to unobserve property: %w", err)
 		}
@@ -213,33 +212,101 @@ func (c *MPVClient) ObserveProperty(property string, fn func(any)) (rm func() er
 	}, nil
 }
 
+// reapplyAfterReconnect is called once the IPC transport has re-dialed. It
+// re-issues observe_property for every subscription registered through
+// ObserveProperty, since a freshly (re)started mpv process won't remember
+// it.
+func (c *MPVClient) reapplyAfterReconnect() {
+	c.subsMu.Lock()
+	subs := make(map[int64]string, len(c.subs))
+	for id, prop := range c.subs {
+		subs[id] = prop
+	}
+	c.subsMu.Unlock()
+	for id, prop := range subs {
+		c.CommandAsync("observe_property", id, prop)
+	}
+}
+
+// runKeepalive issues a cheap no-op command every interval and forces a
+// reconnect if it goes unanswered for timeout, so a half-open connection is
+// noticed even though the socket itself never errors.
+func (c *MPVClient) runKeepalive(interval, timeout time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.ipc.closeCh:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			_, err := c.CommandContext(ctx, "get_property_string", "client-name")
+			cancel()
+			if err != nil {
+				c.ipc.forceReconnect()
+			}
+		}
+	}
+}
+
 // Command sends a command to MPV. See https://mpv.io/manual/stable/#list-of-input-commands
 // for a list of commands and their arguments.
 func (c *MPVClient) Command(command string, args ...any) (any, error) {
-	return c.command(false, command, args...)
+	return c.command(context.Background(), false, command, args...)
 }
 
 // CommandAsync sends a command to MPV as an asynchronous command.
 func (c *MPVClient) CommandAsync(command string, args ...any) (any, error) {
-	return c.command(true, command, args...)
+	return c.command(context.Background(), true, command, args...)
+}
+
+// CommandContext is like Command, but the request is canceled if ctx is
+// canceled before mpv responds.
+func (c *MPVClient) CommandContext(ctx context.Context, command string, args ...any) (any, error) {
+	return c.command(ctx, false, command, args...)
+}
+
+// CommandAsyncContext is like CommandAsync, but the request is canceled if
+// ctx is canceled before mpv responds.
+func (c *MPVClient) CommandAsyncContext(ctx context.Context, command string, args ...any) (any, error) {
+	return c.command(ctx, true, command, args...)
 }
 
+// AddEventHandlerSync adds a synchronous event handler to the client. This
+// handler will block the event loop until it returns, so it is called
+// directly rather than through a queue.
 func (c *MPVClient) AddEventHandlerSync(fn func(map[string]any)) (rm func()) {
 	c.eventHandlersMu.Lock()
 	defer c.eventHandlersMu.Unlock()
-	handler := &eventHandler{sync: true, fn: fn}
+	handler := newEventHandler(true, fn)
 	c.eventHandlers = append(c.eventHandlers, handler)
 	return c.removeEventHandler(handler)
 }
 
-func (c *MPVClient) AddEventHandler(fn func(map[string]any)) (rm func()) {
+// AddEventHandler adds an event handler to the client. Unlike
+// AddEventHandlerSync, this handler gets its own bounded queue and dedicated
+// goroutine, so a slow handler cannot stall delivery to other handlers. By
+// default the queue has room for defaultEventQueueCapacity events and drops
+// the oldest queued event when full; pass options to change the capacity or
+// overflow policy.
+func (c *MPVClient) AddEventHandler(fn func(map[string]any), opts ...EventHandlerOption) (rm func()) {
 	c.eventHandlersMu.Lock()
 	defer c.eventHandlersMu.Unlock()
-	handler := &eventHandler{sync: false, fn: fn}
+	handler := newEventHandler(false, fn, opts...)
+	handler.reportDrop = func() { c.droppedEvents.Add(1) }
 	c.eventHandlers = append(c.eventHandlers, handler)
 	return c.removeEventHandler(handler)
 }
 
+// DroppedEvents returns the number of events lost across every
+// AddEventHandler queue on this client because of an overflow policy other
+// than OverflowBlock. The count is cumulative and survives the handlers that
+// produced it being removed.
+func (c *MPVClient) DroppedEvents() int64 {
+	return c.droppedEvents.Load()
+}
+
 func (c *MPVClient) removeEventHandler(handler *eventHandler) func() {
 	return func() {
 		c.eventHandlersMu.Lock()
@@ -247,6 +314,7 @@ func (c *MPVClient) removeEventHandler(handler *eventHandler) func() {
 		for i, h := range c.eventHandlers {
 			if h == handler {
 				c.eventHandlers = append(c.eventHandlers[:i], c.eventHandlers[i+1:]...)
+				h.close()
 				return
 			}
 		}
@@ -256,25 +324,33 @@ func (c *MPVClient) removeEventHandler(handler *eventHandler) func() {
 func (c *MPVClient) acceptEvents() {
 	for event := range c.ipc.events {
 		c.eventHandlersMu.Lock()
-		for _, handler := range c.eventHandlers {
-			if handler.sync {
-				handler.fn(event)
-			} else {
-				go handler.fn(event)
+		handlers := append([]*eventHandler(nil), c.eventHandlers...)
+		c.eventHandlersMu.Unlock()
+
+		for _, handler := range handlers {
+			if handler.dispatch(event) {
+				c.removeEventHandler(handler)()
 			}
 		}
-		c.eventHandlersMu.Unlock()
 	}
 }
 
-func (c *MPVClient) command(async bool, command string, args ...any) (data any, err error) {
+func (c *MPVClient) command(ctx context.Context, async bool, command string, args ...any) (data any, err error) {
 	args = append([]any{command}, args...)
-	resp, err := c.ipc.sendCommand(async, args...)
+	req, err := c.ipc.startRequest(ctx, async, args...)
 	if err != nil {
 		return nil, err
 	}
-	if !resp.isSuccess() {
-		return nil, fmt.Errorf("mpv: command failed: %s", resp.Error)
+
+	select {
+	case resp := <-req.resp:
+		if !resp.Success() {
+			return nil, fmt.Errorf("mpv: command failed: %s", resp.Error)
+		}
+		return resp.Data, nil
+	case err := <-req.err:
+		return nil, err
+	case <-ctx.Done():
+		return nil, ctx.Err()
 	}
-	return resp.Data, nil
 }
@@ -0,0 +1,245 @@
+package mpv
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Event is implemented by every typed mpv event. EventName returns the raw
+// mpv event name (e.g. "property-change") as documented at
+// https://mpv.io/manual/stable/#list-of-events.
+type Event interface {
+	EventName() string
+}
+
+// EndFileReason is the "reason" field of an EventEndFile.
+type EndFileReason string
+
+const (
+	EndFileReasonEOF      EndFileReason = "eof"
+	EndFileReasonStop     EndFileReason = "stop"
+	EndFileReasonQuit     EndFileReason = "quit"
+	EndFileReasonError    EndFileReason = "error"
+	EndFileReasonRedirect EndFileReason = "redirect"
+	EndFileReasonUnknown  EndFileReason = "unknown"
+)
+
+type EventStartFile struct {
+	PlaylistEntryID int64
+}
+
+func (EventStartFile) EventName() string { return "start-file" }
+
+type EventEndFile struct {
+	Reason          EndFileReason
+	PlaylistEntryID int64
+	Error           string
+}
+
+func (EventEndFile) EventName() string { return "end-file" }
+
+type EventFileLoaded struct{}
+
+func (EventFileLoaded) EventName() string { return "file-loaded" }
+
+type EventSeek struct{}
+
+func (EventSeek) EventName() string { return "seek" }
+
+type EventPlaybackRestart struct{}
+
+func (EventPlaybackRestart) EventName() string { return "playback-restart" }
+
+type EventShutdown struct{}
+
+func (EventShutdown) EventName() string { return "shutdown" }
+
+// EventPropertyChange is delivered for every property observed with
+// ObserveProperty/ObservePropertyTyped. Data holds the raw decoded JSON value;
+// ObservePropertyTyped unwraps it into the requested type for you.
+type EventPropertyChange struct {
+	ID   int64
+	Name string
+	Data any
+}
+
+func (EventPropertyChange) EventName() string { return "property-change" }
+
+type EventLogMessage struct {
+	Prefix string
+	Level  string
+	Text   string
+}
+
+func (EventLogMessage) EventName() string { return "log-message" }
+
+type EventClientMessage struct {
+	Args []string
+}
+
+func (EventClientMessage) EventName() string { return "client-message" }
+
+type EventHook struct {
+	Name   string
+	HookID int64
+	ID     int64
+}
+
+func (EventHook) EventName() string { return "hook" }
+
+// EventUnknown is produced for any mpv event without a dedicated Go type.
+// Raw holds the undecoded JSON object.
+type EventUnknown struct {
+	Name string
+	Raw  map[string]any
+}
+
+func (e EventUnknown) EventName() string { return e.Name }
+
+func decodeEvent(raw map[string]any) Event {
+	name, _ := raw["event"].(string)
+	switch name {
+	case "start-file":
+		return EventStartFile{PlaylistEntryID: asInt64(raw["playlist_entry_id"])}
+	case "end-file":
+		return EventEndFile{
+			Reason:          EndFileReason(asString(raw["reason"])),
+			PlaylistEntryID: asInt64(raw["playlist_entry_id"]),
+			Error:           asString(raw["file_error"]),
+		}
+	case "file-loaded":
+		return EventFileLoaded{}
+	case "seek":
+		return EventSeek{}
+	case "playback-restart":
+		return EventPlaybackRestart{}
+	case "shutdown":
+		return EventShutdown{}
+	case "property-change":
+		return EventPropertyChange{
+			ID:   asInt64(raw["id"]),
+			Name: asString(raw["name"]),
+			Data: raw["data"],
+		}
+	case "log-message":
+		return EventLogMessage{
+			Prefix: asString(raw["prefix"]),
+			Level:  asString(raw["level"]),
+			Text:   asString(raw["text"]),
+		}
+	case "client-message":
+		args, _ := raw["args"].([]any)
+		strs := make([]string, len(args))
+		for i, a := range args {
+			strs[i], _ = a.(string)
+		}
+		return EventClientMessage{Args: strs}
+	case "hook":
+		return EventHook{
+			Name:   asString(raw["name"]),
+			HookID: asInt64(raw["hook_id"]),
+			ID:     asInt64(raw["id"]),
+		}
+	default:
+		return EventUnknown{Name: name, Raw: raw}
+	}
+}
+
+func asInt64(v any) int64 {
+	f, _ := v.(float64)
+	return int64(f)
+}
+
+func asString(v any) string {
+	s, _ := v.(string)
+	return s
+}
+
+// eventSource is implemented by both MPVClient and Client, letting
+// AddTypedEventHandler work on either.
+type eventSource interface {
+	AddEventHandler(fn func(map[string]any), opts ...EventHandlerOption) (rm func())
+}
+
+// AddTypedEventHandler registers fn to be called whenever c receives an event
+// that decodes to T, e.g. AddTypedEventHandler(c, func(e mpv.EventEndFile) {
+// ... }). It is built on top of AddEventHandler, so opts configures the same
+// per-subscriber queue behavior.
+//
+// Go does not allow methods to introduce their own type parameters, so this
+// is a package-level function rather than a method on MPVClient/Client.
+func AddTypedEventHandler[T Event](c eventSource, fn func(T), opts ...EventHandlerOption) (rm func()) {
+	return c.AddEventHandler(func(raw map[string]any) {
+		if typed, ok := decodeEvent(raw).(T); ok {
+			fn(typed)
+		}
+	}, opts...)
+}
+
+// Events returns a channel of typed events alongside a function to stop
+// delivering to it. The channel is backed by its own event handler queue
+// (see AddEventHandler) and is never closed, so callers should stop reading
+// from it once rm has been called.
+func (c *MPVClient) Events(opts ...EventHandlerOption) (events <-chan Event, rm func()) {
+	ch := make(chan Event, defaultEventQueueCapacity)
+	rm = c.AddEventHandler(func(raw map[string]any) {
+		ch <- decodeEvent(raw)
+	}, opts...)
+	return ch, rm
+}
+
+// ObservePropertyTyped observes property and calls fn with its value decoded
+// into T, handling mpv's numeric widening (e.g. float64 -> int64) and
+// decoding node/array/map properties such as track-list or playlist into
+// struct slices via JSON. fn is skipped if the value can't be converted to T.
+//
+// Like AddTypedEventHandler, this is a package-level function because Go
+// methods can't introduce new type parameters.
+func ObservePropertyTyped[T any](c *MPVClient, property string, fn func(T)) (rm func() error, err error) {
+	return c.ObserveProperty(property, func(data any) {
+		if v, ok := convertPropertyValue[T](data); ok {
+			fn(v)
+		}
+	})
+}
+
+func convertPropertyValue[T any](data any) (T, bool) {
+	var zero T
+	if data == nil {
+		return zero, false
+	}
+	if v, ok := data.(T); ok {
+		return v, true
+	}
+
+	switch any(zero).(type) {
+	case int64:
+		if f, ok := data.(float64); ok {
+			return any(int64(f)).(T), true
+		}
+	case int:
+		if f, ok := data.(float64); ok {
+			return any(int(f)).(T), true
+		}
+	case float32:
+		if f, ok := data.(float64); ok {
+			return any(float32(f)).(T), true
+		}
+	case time.Duration:
+		if f, ok := data.(float64); ok {
+			return any(time.Duration(f * float64(time.Second))).(T), true
+		}
+	}
+
+	// Fall back to a JSON round-trip, which covers node/array/map properties
+	// like track-list, playlist, and chapter-list decoding into struct slices.
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return zero, false
+	}
+	var out T
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return zero, false
+	}
+	return out, true
+}
@@ -28,7 +28,7 @@ func (p *MPVProcess) OpenClient() (*MPVClient, error) {
 		return nil, err
 	}
 
-	client, err := OpenClient()
+	client, err := OpenMPVClient()
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,34 @@
+package mpvgw
+
+import (
+	"crypto/subtle"
+	"net/http"
+)
+
+// Authenticator decides whether an incoming request is allowed to reach the
+// gateway. It returns an error describing why the request was rejected, or
+// nil to let it through.
+type Authenticator interface {
+	Authenticate(r *http.Request) error
+}
+
+// AuthenticatorFunc adapts a function to an Authenticator.
+type AuthenticatorFunc func(r *http.Request) error
+
+func (f AuthenticatorFunc) Authenticate(r *http.Request) error { return f(r) }
+
+// BearerToken returns an Authenticator that requires an
+// "Authorization: Bearer <token>" header matching token exactly. mTLS is
+// expected to be enforced by the http.Server's TLSConfig (ClientAuth:
+// tls.RequireAndVerifyClientCert) rather than through this interface, since
+// it's negotiated below the HTTP layer.
+func BearerToken(token string) Authenticator {
+	want := []byte("Bearer " + token)
+	return AuthenticatorFunc(func(r *http.Request) error {
+		got := []byte(r.Header.Get("Authorization"))
+		if subtle.ConstantTimeCompare(got, want) != 1 {
+			return errUnauthorized
+		}
+		return nil
+	})
+}
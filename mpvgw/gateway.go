@@ -0,0 +1,41 @@
+// Package mpvgw re-exports a *mpv.Client over HTTP+JSON (with an SSE event
+// stream) and over gRPC so mpv running on one machine can be driven from
+// phones, web UIs, or other services without speaking the raw JSON-IPC
+// protocol directly. See Gateway.Handler for the HTTP routes, which call the
+// wrapped Client directly, and NewGRPCServer for the gRPC service, which
+// forwards to GatewayService. The gRPC messages are plain Go structs marshaled as JSON
+// rather than generated proto.Message types (see grpc.go), since no
+// protoc/buf toolchain is available in this module's build environment.
+package mpvgw
+
+import (
+	"github.com/xoltia/mpv"
+)
+
+// Gateway wraps a *mpv.Client and exposes it through Handler (HTTP+JSON+SSE).
+type Gateway struct {
+	client *mpv.Client
+	auth   Authenticator
+
+	props *propertyHub
+}
+
+// Option configures a Gateway.
+type Option func(*Gateway)
+
+// WithAuthenticator sets how incoming requests are authenticated. Without
+// one, the gateway accepts every request unauthenticated, which is only
+// appropriate behind another trusted boundary (e.g. a local-only socket).
+func WithAuthenticator(auth Authenticator) Option {
+	return func(g *Gateway) { g.auth = auth }
+}
+
+// NewGateway returns a Gateway wrapping client.
+func NewGateway(client *mpv.Client, opts ...Option) *Gateway {
+	g := &Gateway{client: client}
+	for _, opt := range opts {
+		opt(g)
+	}
+	g.props = newPropertyHub(client)
+	return g
+}
@@ -0,0 +1,380 @@
+package mpvgw
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/xoltia/mpv"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// jsonCodecName is the name registered with grpc's encoding package. Using
+// it in place of the default "proto" codec is what lets RemoteControl be
+// served without a .proto/protoc step: the message types below are plain Go
+// structs, not generated proto.Message implementations, so they're
+// marshaled as JSON instead of the protobuf wire format.
+const jsonCodecName = "mpvgw-json"
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Name() string                       { return jsonCodecName }
+func (jsonCodec) Marshal(v any) ([]byte, error)      { return json.Marshal(v) }
+func (jsonCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+
+// Request/response message types for the RemoteControl service, one per
+// REST route in http.go plus the Events stream.
+
+type Empty struct{}
+
+type SeekRequest struct {
+	Position float64  `json:"position"`
+	Flags    []string `json:"flags"`
+}
+
+type LoadFileRequest struct {
+	File string `json:"file"`
+	Mode string `json:"mode"`
+}
+
+type GetPropertyRequest struct {
+	Name string `json:"name"`
+}
+
+type GetPropertyResponse struct {
+	Value any `json:"value"`
+}
+
+type EventsRequest struct{}
+
+type Event struct {
+	Name string `json:"name"`
+	Data any    `json:"data"`
+}
+
+// remoteControlServer is the interface grpc.ServiceDesc below dispatches
+// to: one unary RPC per REST route, plus a server-streaming Events RPC.
+// It's the same shape protoc-gen-go-grpc would generate from a .proto
+// definition; it's written by hand here since no protoc/buf toolchain is
+// available in this module's build environment.
+type remoteControlServer interface {
+	Play(context.Context, *Empty) (*Empty, error)
+	Pause(context.Context, *Empty) (*Empty, error)
+	Seek(context.Context, *SeekRequest) (*Empty, error)
+	LoadFile(context.Context, *LoadFileRequest) (*Empty, error)
+	GetProperty(context.Context, *GetPropertyRequest) (*GetPropertyResponse, error)
+	Events(*EventsRequest, RemoteControl_EventsServer) error
+}
+
+// RemoteControl_EventsServer is the server-side stream handle passed to
+// remoteControlServer.Events, matching the shape a generated
+// *_EventsServer interface would have for a server-streaming RPC.
+type RemoteControl_EventsServer interface {
+	Send(*Event) error
+	grpc.ServerStream
+}
+
+type remoteControlEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *remoteControlEventsServer) Send(e *Event) error {
+	return x.ServerStream.SendMsg(e)
+}
+
+func _RemoteControl_Play_Handler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(remoteControlServer).Play(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/mpvgw.RemoteControl/Play"}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return srv.(remoteControlServer).Play(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemoteControl_Pause_Handler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(remoteControlServer).Pause(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/mpvgw.RemoteControl/Pause"}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return srv.(remoteControlServer).Pause(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemoteControl_Seek_Handler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := new(SeekRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(remoteControlServer).Seek(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/mpvgw.RemoteControl/Seek"}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return srv.(remoteControlServer).Seek(ctx, req.(*SeekRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemoteControl_LoadFile_Handler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := new(LoadFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(remoteControlServer).LoadFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/mpvgw.RemoteControl/LoadFile"}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return srv.(remoteControlServer).LoadFile(ctx, req.(*LoadFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemoteControl_GetProperty_Handler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := new(GetPropertyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(remoteControlServer).GetProperty(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/mpvgw.RemoteControl/GetProperty"}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return srv.(remoteControlServer).GetProperty(ctx, req.(*GetPropertyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemoteControl_Events_Handler(srv any, stream grpc.ServerStream) error {
+	m := new(EventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(remoteControlServer).Events(m, &remoteControlEventsServer{stream})
+}
+
+// RemoteControl_ServiceDesc describes the RemoteControl gRPC service: the
+// same grpc.ServiceDesc a generated *_grpc.pb.go file would define.
+var RemoteControl_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "mpvgw.RemoteControl",
+	HandlerType: (*remoteControlServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "Play", Handler: _RemoteControl_Play_Handler},
+		{MethodName: "Pause", Handler: _RemoteControl_Pause_Handler},
+		{MethodName: "Seek", Handler: _RemoteControl_Seek_Handler},
+		{MethodName: "LoadFile", Handler: _RemoteControl_LoadFile_Handler},
+		{MethodName: "GetProperty", Handler: _RemoteControl_GetProperty_Handler},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Events",
+			Handler:       _RemoteControl_Events_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "mpvgw/grpc.go",
+}
+
+// grpcServer adapts GatewayService's plain-error methods to the
+// message-typed, status-coded signatures remoteControlServer requires.
+type grpcServer struct {
+	svc *GatewayService
+}
+
+func (s *grpcServer) Play(ctx context.Context, _ *Empty) (*Empty, error) {
+	if err := s.svc.Play(ctx); err != nil {
+		return nil, status.Error(codes.Unknown, err.Error())
+	}
+	return &Empty{}, nil
+}
+
+func (s *grpcServer) Pause(ctx context.Context, _ *Empty) (*Empty, error) {
+	if err := s.svc.Pause(ctx); err != nil {
+		return nil, status.Error(codes.Unknown, err.Error())
+	}
+	return &Empty{}, nil
+}
+
+func (s *grpcServer) Seek(ctx context.Context, req *SeekRequest) (*Empty, error) {
+	if err := s.svc.Seek(ctx, req.Position, req.Flags); err != nil {
+		return nil, status.Error(codes.Unknown, err.Error())
+	}
+	return &Empty{}, nil
+}
+
+func (s *grpcServer) LoadFile(ctx context.Context, req *LoadFileRequest) (*Empty, error) {
+	if err := s.svc.LoadFile(ctx, req.File, req.Mode); err != nil {
+		return nil, status.Error(codes.Unknown, err.Error())
+	}
+	return &Empty{}, nil
+}
+
+func (s *grpcServer) GetProperty(ctx context.Context, req *GetPropertyRequest) (*GetPropertyResponse, error) {
+	value, err := s.svc.GetProperty(ctx, req.Name)
+	if err != nil {
+		return nil, status.Error(codes.Unknown, err.Error())
+	}
+	return &GetPropertyResponse{Value: value}, nil
+}
+
+func (s *grpcServer) Events(_ *EventsRequest, stream RemoteControl_EventsServer) error {
+	return s.svc.Events(stream.Context(), func(name string, data any) error {
+		return stream.Send(&Event{Name: name, Data: data})
+	})
+}
+
+// RegisterRemoteControlServer registers gw's RemoteControl gRPC service on
+// s, exactly like a generated RegisterRemoteControlServer function would.
+func RegisterRemoteControlServer(s *grpc.Server, gw *Gateway) {
+	s.RegisterService(&RemoteControl_ServiceDesc, &grpcServer{svc: NewGatewayService(gw)})
+}
+
+// NewGRPCServer returns a *grpc.Server exposing gw's RemoteControl service:
+// one unary RPC per REST route in http.go, plus a server-streaming Events
+// RPC. It forces the JSON codec registered by this package in place of the
+// default protobuf codec, since RemoteControl's messages are plain Go
+// structs rather than generated proto.Message types. If gw was built with
+// WithAuthenticator, every RPC (including Events) is checked against it
+// first, exactly like the HTTP handler's g.withAuth.
+func NewGRPCServer(gw *Gateway, opts ...grpc.ServerOption) *grpc.Server {
+	serverOpts := []grpc.ServerOption{grpc.ForceServerCodec(jsonCodec{})}
+	if gw.auth != nil {
+		serverOpts = append(serverOpts,
+			grpc.ChainUnaryInterceptor(authUnaryInterceptor(gw.auth)),
+			grpc.ChainStreamInterceptor(authStreamInterceptor(gw.auth)),
+		)
+	}
+	serverOpts = append(serverOpts, opts...)
+
+	s := grpc.NewServer(serverOpts...)
+	RegisterRemoteControlServer(s, gw)
+	return s
+}
+
+// authUnaryInterceptor rejects unary RPCs that fail auth before they reach
+// grpcServer.
+func authUnaryInterceptor(auth Authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := authenticateGRPC(ctx, auth); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// authStreamInterceptor rejects streaming RPCs (Events) that fail auth
+// before they reach grpcServer.
+func authStreamInterceptor(auth Authenticator) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := authenticateGRPC(ss.Context(), auth); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// authenticateGRPC adapts Authenticator — shaped around *http.Request for
+// the HTTP gateway — to gRPC by synthesizing a request whose Header carries
+// the incoming call's metadata, so the same Authenticator implementation
+// (e.g. BearerToken) works unchanged on both transports.
+func authenticateGRPC(ctx context.Context, auth Authenticator) error {
+	header := make(http.Header)
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for k, vs := range md {
+			for _, v := range vs {
+				header.Add(k, v)
+			}
+		}
+	}
+	if err := auth.Authenticate(&http.Request{Header: header}); err != nil {
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+	return nil
+}
+
+// Service describes the RPCs RemoteControl exposes as a plain Go interface
+// with no gRPC framing, for callers that want to drive a Gateway
+// in-process (e.g. tests) without going over the wire.
+type Service interface {
+	Play(ctx context.Context) error
+	Pause(ctx context.Context) error
+	Seek(ctx context.Context, position float64, flags []string) error
+	LoadFile(ctx context.Context, file, mode string) error
+	GetProperty(ctx context.Context, name string) (any, error)
+	// Events streams events to onEvent until ctx is canceled or onEvent
+	// returns an error, mirroring RemoteControl's Events RPC handler loop.
+	Events(ctx context.Context, onEvent func(name string, data any) error) error
+}
+
+// GatewayService implements Service by calling straight through to a
+// Gateway's underlying Client, with no HTTP or gRPC framing involved. It
+// also backs the gRPC RemoteControl service via grpcServer above.
+type GatewayService struct {
+	gw *Gateway
+}
+
+// NewGatewayService returns a Service backed by gw.
+func NewGatewayService(gw *Gateway) *GatewayService {
+	return &GatewayService{gw: gw}
+}
+
+func (s *GatewayService) Play(ctx context.Context) error  { return s.gw.client.Play(ctx) }
+func (s *GatewayService) Pause(ctx context.Context) error { return s.gw.client.Pause(ctx) }
+
+func (s *GatewayService) Seek(ctx context.Context, position float64, flags []string) error {
+	mpvFlags := make([]mpv.SeekFlag, len(flags))
+	for i, f := range flags {
+		mpvFlags[i] = mpv.SeekFlag(f)
+	}
+	return s.gw.client.Seek(ctx, position, mpvFlags...)
+}
+
+func (s *GatewayService) LoadFile(ctx context.Context, file, mode string) error {
+	if mode == "" {
+		mode = "replace"
+	}
+	return s.gw.client.LoadFile(ctx, file, mpv.LoadFileMode(mode))
+}
+
+func (s *GatewayService) GetProperty(ctx context.Context, name string) (any, error) {
+	return s.gw.client.GetProperty(ctx, name)
+}
+
+func (s *GatewayService) Events(ctx context.Context, onEvent func(name string, data any) error) error {
+	events, rm := s.gw.client.Events()
+	defer rm()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if err := onEvent(event.EventName(), event); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,237 @@
+package mpvgw
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/xoltia/mpv"
+)
+
+var errUnauthorized = errors.New("mpvgw: unauthorized")
+
+// Handler returns an http.Handler exposing the gateway's REST/JSON routes:
+//
+//	POST /play
+//	POST /pause
+//	POST /seek            {"position": 12.5, "flags": ["relative"]}
+//	POST /loadfile        {"file": "...", "mode": "replace"}
+//	GET  /properties/{name}             one-shot value
+//	GET  /properties/{name}?watch=true  text/event-stream of changes
+//	GET  /events                        text/event-stream of typed mpv events
+func (g *Gateway) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/play", g.handlePlay)
+	mux.HandleFunc("/pause", g.handlePause)
+	mux.HandleFunc("/seek", g.handleSeek)
+	mux.HandleFunc("/loadfile", g.handleLoadFile)
+	mux.HandleFunc("/properties/", g.handleGetProperty)
+	mux.HandleFunc("/events", g.handleEvents)
+
+	return g.withAuth(mux)
+}
+
+func (g *Gateway) withAuth(next http.Handler) http.Handler {
+	if g.auth == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := g.auth.Authenticate(r); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (g *Gateway) handlePlay(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodPost) {
+		return
+	}
+	if err := g.client.Play(r.Context()); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (g *Gateway) handlePause(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodPost) {
+		return
+	}
+	if err := g.client.Pause(r.Context()); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type seekRequest struct {
+	Position float64  `json:"position"`
+	Flags    []string `json:"flags"`
+}
+
+func (g *Gateway) handleSeek(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodPost) {
+		return
+	}
+	var req seekRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	flags := make([]mpv.SeekFlag, len(req.Flags))
+	for i, f := range req.Flags {
+		flags[i] = mpv.SeekFlag(f)
+	}
+	if err := g.client.Seek(r.Context(), req.Position, flags...); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type loadFileRequest struct {
+	File string `json:"file"`
+	Mode string `json:"mode"`
+}
+
+func (g *Gateway) handleLoadFile(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodPost) {
+		return
+	}
+	var req loadFileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.Mode == "" {
+		req.Mode = "replace"
+	}
+	if err := g.client.LoadFile(r.Context(), req.File, mpv.LoadFileMode(req.Mode)); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleGetProperty serves GET /properties/{name}. With ?watch=true it
+// instead streams every change to the property as Server-Sent Events,
+// backed by the gateway's propertyHub so any number of remote watchers of
+// the same property share a single underlying observe_property.
+func (g *Gateway) handleGetProperty(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodGet) {
+		return
+	}
+	name := strings.TrimPrefix(r.URL.Path, "/properties/")
+	if name == "" {
+		http.Error(w, "missing property name", http.StatusBadRequest)
+		return
+	}
+
+	if r.URL.Query().Get("watch") == "true" {
+		g.streamProperty(w, r, name)
+		return
+	}
+
+	value, err := g.client.GetProperty(r.Context(), name)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, map[string]any{"name": name, "value": value})
+}
+
+func (g *Gateway) streamProperty(w http.ResponseWriter, r *http.Request, name string) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	ch, unwatch, err := g.props.watch(name)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	defer unwatch()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case value, ok := <-ch:
+			if !ok {
+				return
+			}
+			payload, err := json.Marshal(map[string]any{"name": name, "value": value})
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "event: property-change\ndata: %s\n\n", payload)
+			flusher.Flush()
+		}
+	}
+}
+
+// handleEvents streams the gateway's typed mpv events as Server-Sent
+// Events, one JSON object per event, until the client disconnects.
+func (g *Gateway) handleEvents(w http.ResponseWriter, r *http.Request) {
+	if !requireMethod(w, r, http.MethodGet) {
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	events, rm := g.client.Events()
+	defer rm()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			payload, err := json.Marshal(map[string]any{"event": event.EventName(), "data": event})
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.EventName(), payload)
+			flusher.Flush()
+		}
+	}
+}
+
+func requireMethod(w http.ResponseWriter, r *http.Request, method string) bool {
+	if r.Method != method {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	http.Error(w, err.Error(), http.StatusBadGateway)
+}
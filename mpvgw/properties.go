@@ -0,0 +1,117 @@
+package mpvgw
+
+import (
+	"context"
+	"sync"
+
+	"github.com/xoltia/mpv"
+)
+
+// propertyHub multiplexes any number of remote subscribers onto a single
+// mpv.Client.ObserveProperty call per property name, so N gateway clients
+// watching "time-pos" cost mpv exactly one observe_property, not N.
+type propertyHub struct {
+	client *mpv.Client
+
+	// ctx bounds the underlying mpv.Client.ObserveProperty subscriptions.
+	// It must outlive any single watcher's request, since a subscription is
+	// shared across watchers and torn down on its own schedule (the last
+	// unwatch), not on a borrowed per-request context's cancellation.
+	ctx context.Context
+
+	mu   sync.Mutex
+	subs map[string]*propertySub
+}
+
+type propertySub struct {
+	rm        func() error
+	listeners map[int]chan any
+	nextID    int
+}
+
+func newPropertyHub(client *mpv.Client) *propertyHub {
+	return &propertyHub{client: client, ctx: context.Background(), subs: make(map[string]*propertySub)}
+}
+
+// watch delivers every value of property to ch until unwatch is called. The
+// underlying mpv observe_property is shared across all watchers of the same
+// property, runs under the hub's own long-lived context rather than any one
+// watcher's, and is only torn down once the last one unwatches.
+func (h *propertyHub) watch(property string) (ch <-chan any, unwatch func(), err error) {
+	h.mu.Lock()
+	sub, ok := h.subs[property]
+	if !ok {
+		sub = &propertySub{listeners: make(map[int]chan any)}
+		h.subs[property] = sub
+	}
+
+	rm, werr := func() (func() error, error) {
+		if ok {
+			return sub.rm, nil
+		}
+		return h.client.ObserveProperty(h.ctx, property, func(value any) {
+			h.broadcast(property, value)
+		})
+	}()
+	if werr != nil {
+		if !ok {
+			delete(h.subs, property)
+		}
+		h.mu.Unlock()
+		return nil, nil, werr
+	}
+	sub.rm = rm
+
+	id := sub.nextID
+	sub.nextID++
+	out := make(chan any, 16)
+	sub.listeners[id] = out
+	h.mu.Unlock()
+
+	return out, func() { h.unwatch(property, id) }, nil
+}
+
+func (h *propertyHub) broadcast(property string, value any) {
+	h.mu.Lock()
+	sub, ok := h.subs[property]
+	if !ok {
+		h.mu.Unlock()
+		return
+	}
+	listeners := make([]chan any, 0, len(sub.listeners))
+	for _, ch := range sub.listeners {
+		listeners = append(listeners, ch)
+	}
+	h.mu.Unlock()
+
+	for _, ch := range listeners {
+		select {
+		case ch <- value:
+		default:
+			// A slow remote subscriber shouldn't block delivery to others
+			// or to mpv's own event pump; it just misses this update.
+		}
+	}
+}
+
+func (h *propertyHub) unwatch(property string, id int) {
+	h.mu.Lock()
+	sub, ok := h.subs[property]
+	if !ok {
+		h.mu.Unlock()
+		return
+	}
+	if ch, ok := sub.listeners[id]; ok {
+		close(ch)
+		delete(sub.listeners, id)
+	}
+	empty := len(sub.listeners) == 0
+	if empty {
+		delete(h.subs, property)
+	}
+	h.mu.Unlock()
+
+	if empty {
+		sub.rm()
+	}
+}
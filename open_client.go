@@ -2,12 +2,18 @@ package mpv
 
 import (
 	"fmt"
+	"net"
 	"time"
 )
 
 type openClientOptions struct {
 	socketPath  string
 	dialTimeout time.Duration
+
+	// reconnect enables automatic reconnection of the IPC transport. Nil
+	// keeps the default behavior of failing permanently when the
+	// connection is lost.
+	reconnect *ReconnectPolicy
 }
 
 func (o *openClientOptions) applyDefaults() {
@@ -33,7 +39,19 @@ func WithDialTimeout(timeout time.Duration) OpenClientOption {
 	}
 }
 
-func OpenClient(options ...OpenClientOption) (*MPVClient, error) {
+// WithReconnect enables automatic reconnection of the IPC transport: on
+// connection loss, the socket is re-dialed with exponential backoff,
+// observe_property subscriptions registered through ObserveProperty are
+// re-issued, and in-flight requests are replayed or failed with
+// ErrDisconnected. Omit it to keep the default behavior of failing
+// permanently when the connection is lost.
+func WithReconnect(policy ReconnectPolicy) OpenClientOption {
+	return func(o *openClientOptions) {
+		o.reconnect = &policy
+	}
+}
+
+func OpenMPVClient(options ...OpenClientOption) (*MPVClient, error) {
 	var opts openClientOptions
 	for _, o := range options {
 		o(&opts)
@@ -46,6 +64,20 @@ func OpenClient(options ...OpenClientOption) (*MPVClient, error) {
 	}
 
 	client := &MPVClient{ipc: ipc}
+
+	if opts.reconnect != nil {
+		policy := *opts.reconnect
+		policy.applyDefaults()
+		dial := func() (net.Conn, error) {
+			return dialTransport(opts.socketPath, opts.dialTimeout)
+		}
+		ipc.enableReconnect(policy, dial, client.reapplyAfterReconnect)
+
+		if policy.KeepaliveInterval > 0 {
+			go client.runKeepalive(policy.KeepaliveInterval, policy.KeepaliveTimeout)
+		}
+	}
+
 	go client.acceptEvents()
 	return client, nil
 }
@@ -123,8 +123,13 @@ func (p *Process) startProcess() error {
 		return nil
 	}
 
+	ipcServer, err := inputIPCServerArg(p.opts.ClientOptions.SocketPath)
+	if err != nil {
+		return err
+	}
+
 	defaultArgs := []string{
-		fmt.Sprintf("--input-ipc-server=%s", p.opts.ClientOptions.SocketPath),
+		fmt.Sprintf("--input-ipc-server=%s", ipcServer),
 		"--idle",
 	}
 	args := append(defaultArgs, p.opts.Args...)